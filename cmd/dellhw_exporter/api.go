@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	apiInventoryPath = "/api/v1/inventory"
+	apiStatusPath    = "/api/v1/status"
+
+	// errNotCollected is returned until the first collection (a scrape, push or textfile write) finished
+	errNotCollected = "nothing collected yet, the data is available after the first scrape"
+)
+
+// apiInventory is the response of the inventory endpoint
+type apiInventory struct {
+	Subsystems map[string][]apiInventoryComponent `json:"subsystems"`
+}
+
+// apiInventoryComponent is a component (e.g., a disk) with its attributes (e.g., the serial number)
+// and readings
+type apiInventoryComponent struct {
+	Labels     map[string]string  `json:"labels"`
+	Attributes map[string]string  `json:"attributes,omitempty"`
+	Readings   map[string]float64 `json:"readings,omitempty"`
+}
+
+// apiError is the response of the endpoints if they can't return the data
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// apiStatus is the response of the status endpoint
+type apiStatus struct {
+	Status     string                          `json:"status"`
+	Collectors map[string]apiCollectorStatus   `json:"collectors"`
+	Subsystems map[string][]apiComponentStatus `json:"subsystems"`
+}
+
+// apiCollectorStatus is the last run of a collector
+type apiCollectorStatus struct {
+	LastRun         time.Time `json:"last_run"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Success         bool      `json:"success"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// apiComponentStatus is the decoded status and state of a component
+type apiComponentStatus struct {
+	Labels map[string]string `json:"labels"`
+	Status string            `json:"status,omitempty"`
+	State  string            `json:"state,omitempty"`
+}
+
+// runMetrics replays the metrics of a collector run
+type runMetrics []prometheus.Metric
+
+// Describe implements the prometheus.Collector interface, no descriptions are sent
+// as the collectors create their descriptions dynamically.
+func (m runMetrics) Describe(ch chan<- *prometheus.Desc) {}
+
+// Collect implements the prometheus.Collector interface.
+func (m runMetrics) Collect(ch chan<- prometheus.Metric) {
+	for _, metric := range m {
+		ch <- metric
+	}
+}
+
+// lastReport returns the components of the last runs of the collectors, false if nothing was collected
+// yet (e.g., no scrape since the start). The collectors aren't run for the API.
+func (p *program) lastReport() (*checkReport, map[string]*collectorRun, bool) {
+	if p.collector.lastCollect().IsZero() {
+		return nil, nil, false
+	}
+
+	runs := p.collector.lastRuns()
+	return p.runsReport(runs), runs, true
+}
+
+// runsReport returns the components of the collector runs, decoded with the options of the collectors
+func (p *program) runsReport(runs map[string]*collectorRun) *checkReport {
+	report := &checkReport{
+		Status: "Ok",
+		Errors: map[string]string{},
+	}
+	for name, run := range runs {
+		components, err := gatherComponents(name, runMetrics(run.metrics), p.omr.Options)
+		if err != nil {
+			report.Errors[name] = err.Error()
+			continue
+		}
+		report.Components = append(report.Components, components...)
+	}
+	report.setStatus()
+
+	return report
+}
+
+// handleInventory returns the components of the last runs of the collectors grouped by collector
+func (p *program) handleInventory(w http.ResponseWriter, r *http.Request) {
+	report, runs, ok := p.lastReport()
+	if !ok {
+		writeAPIError(w, http.StatusServiceUnavailable, errNotCollected)
+		return
+	}
+
+	inventory := apiInventory{Subsystems: make(map[string][]apiInventoryComponent, len(runs))}
+	for name := range runs {
+		inventory.Subsystems[name] = []apiInventoryComponent{}
+	}
+	for _, component := range report.Components {
+		inventory.Subsystems[component.Collector] = append(inventory.Subsystems[component.Collector], apiInventoryComponent{
+			Labels:     component.Labels,
+			Attributes: component.Attributes,
+			Readings:   component.Readings,
+		})
+	}
+
+	writeAPIResponse(w, inventory)
+}
+
+// handleStatus returns the overall status, the last runs of the collectors and the status of the
+// components grouped by collector
+func (p *program) handleStatus(w http.ResponseWriter, r *http.Request) {
+	report, runs, ok := p.lastReport()
+	if !ok {
+		writeAPIError(w, http.StatusServiceUnavailable, errNotCollected)
+		return
+	}
+
+	status := apiStatus{
+		Status:     report.Status,
+		Collectors: make(map[string]apiCollectorStatus, len(runs)),
+		Subsystems: make(map[string][]apiComponentStatus, len(runs)),
+	}
+	for name, run := range runs {
+		cs := apiCollectorStatus{
+			LastRun:         run.time,
+			DurationSeconds: run.duration.Seconds(),
+			Success:         run.err == nil,
+		}
+		if run.err != nil {
+			cs.Error = run.err.Error()
+		} else if err, ok := report.Errors[name]; ok {
+			cs.Error = err
+		}
+		status.Collectors[name] = cs
+		status.Subsystems[name] = []apiComponentStatus{}
+	}
+	for _, component := range report.Components {
+		if component.Status == "" && component.State == "" {
+			continue
+		}
+		status.Subsystems[component.Collector] = append(status.Subsystems[component.Collector], apiComponentStatus{
+			Labels: component.Labels,
+			Status: component.Status,
+			State:  component.State,
+		})
+	}
+
+	writeAPIResponse(w, status)
+}
+
+// writeAPIError writes the error as JSON with the status code
+func writeAPIError(w http.ResponseWriter, code int, err string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	writeJSON(w, apiError{Error: err})
+}
+
+func writeAPIResponse(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	writeJSON(w, v)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		logger.Error("failed to write API response", "error", err.Error())
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAPIProgram(t *testing.T, omrOpts *omreport.Options) *program {
+	t.Helper()
+
+	disk := map[string]string{"controller": "0", "disk": "0_1_0"}
+	backend := collector.NewFakeBackend()
+	backend.Values["StorageController"] = []omreport.Value{{Name: "storage_controller_status", Value: "0", Labels: map[string]string{"id": "0"}}}
+	backend.Values["StoragePdisk"] = []omreport.Value{
+		// "Critical" with the legacy severities
+		{Name: "storage_pdisk_status", Value: "1", Labels: disk},
+		{Name: "storage_pdisk_state", Value: "4", Labels: disk},
+		{Name: "storage_pdisk_info", Value: "0", Labels: map[string]string{"controller": "0", "disk": "0_1_0", "serial_number": "BTHC643503A2200TGN"}},
+		{Name: "storage_pdisk_remaining_rated_write_endurance", Value: "100", Labels: disk},
+	}
+	backend.Values["ChassisBios"] = []omreport.Value{
+		{Name: "bios", Value: "0", Labels: map[string]string{"version": "2.10.5"}},
+	}
+	backend.Errors["Fans"] = errors.New("omreport failed")
+
+	collectors := newCheckCollectors(t, backend, "storage_pdisk", "firmwares", "fans")
+	return &program{collector: NewDellHWCollector(collectors, false, 0), omr: &omreport.OMReport{Options: omrOpts}}
+}
+
+func TestAPINotCollected(t *testing.T) {
+	tc := &testCollector{name: "ok"}
+	p := &program{collector: NewDellHWCollector(map[string]collector.Collector{"ok": tc}, false, 0), omr: &omreport.OMReport{}}
+
+	for _, handler := range []http.HandlerFunc{p.handleInventory, p.handleStatus} {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		resp := apiError{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, errNotCollected, resp.Error)
+	}
+	// The collectors are never run by the API
+	assert.Equal(t, int32(0), tc.updates.Load())
+}
+
+func getAPIResponse(t *testing.T, handler http.HandlerFunc, v any) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), v))
+}
+
+func TestAPIInventory(t *testing.T) {
+	p := newAPIProgram(t, nil)
+	collectAndCount(t, p.collector)
+
+	inventory := apiInventory{}
+	getAPIResponse(t, p.handleInventory, &inventory)
+
+	assert.Equal(t, []apiInventoryComponent{{
+		Labels:     map[string]string{"controller": "0", "disk": "0_1_0"},
+		Attributes: map[string]string{"serial_number": "BTHC643503A2200TGN"},
+		Readings:   map[string]float64{"storage_pdisk_remaining_rated_write_endurance": 100},
+	}}, inventory.Subsystems["storage_pdisk"])
+	assert.Equal(t, []apiInventoryComponent{{
+		Labels: map[string]string{"version": "2.10.5"},
+	}}, inventory.Subsystems["firmwares"])
+	assert.Empty(t, inventory.Subsystems["fans"])
+
+	// The attributes of the disks are only in the API
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(p.collector)
+	count, err := testutil.GatherAndCount(reg, "dell_hw_storage_pdisk_info")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	count, err = testutil.GatherAndCount(reg, "dell_hw_storage_pdisk_status")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestAPIStatus(t *testing.T) {
+	for _, omrOpts := range []*omreport.Options{nil, {SeverityModel: omreport.SeverityModelOrdered, StateSets: true}} {
+		p := newAPIProgram(t, omrOpts)
+		if omrOpts != nil {
+			// The collectors expose the values with the options, the fake backend returns them as is
+			p.collector = NewDellHWCollector(newCheckCollectors(t, stateSetsBackend(), "storage_pdisk"), false, 0)
+		}
+		collectAndCount(t, p.collector)
+
+		status := apiStatus{}
+		getAPIResponse(t, p.handleStatus, &status)
+
+		assert.Equal(t, "Critical", status.Status)
+		assert.Equal(t, []apiComponentStatus{{
+			Labels: map[string]string{"controller": "0", "disk": "0_1_0"},
+			Status: "Critical",
+			State:  "Failed",
+		}}, status.Subsystems["storage_pdisk"])
+		assert.True(t, status.Collectors["storage_pdisk"].Success)
+		assert.False(t, status.Collectors["storage_pdisk"].LastRun.IsZero())
+
+		if omrOpts == nil {
+			assert.False(t, status.Collectors["fans"].Success)
+			assert.Equal(t, "omreport failed", status.Collectors["fans"].Error)
+			assert.Empty(t, status.Subsystems["firmwares"])
+		}
+	}
+}
+
+// stateSetsBackend returns the status and state of a disk as state sets with the ordered severities
+func stateSetsBackend() *collector.FakeBackend {
+	backend := collector.NewFakeBackend()
+	backend.Values["StorageController"] = []omreport.Value{{Name: "storage_controller_status", Value: "0", Labels: map[string]string{"id": "0"}}}
+	for _, state := range []struct {
+		name  string
+		state string
+		value string
+	}{
+		{"storage_pdisk_status", "Ok", "0"},
+		{"storage_pdisk_status", "Critical", "1"},
+		{"storage_pdisk_state", "Online", "0"},
+		{"storage_pdisk_state", "Failed", "1"},
+	} {
+		backend.Values["StoragePdisk"] = append(backend.Values["StoragePdisk"], omreport.Value{
+			Name:   state.name,
+			Value:  state.value,
+			Labels: map[string]string{"controller": "0", "disk": "0_1_0", "state": state.state},
+		})
+	}
+	return backend
+}
@@ -0,0 +1,469 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Exit codes of the check command
+const (
+	checkExitOK       = 0
+	checkExitError    = 1
+	checkExitCritical = 2
+)
+
+// criticalSeverity is the "Critical" value of the omreport.SeverityModelOrdered
+const criticalSeverity = 3
+
+var checkOutput string
+
+// checkReport is the result of running the collectors once
+type checkReport struct {
+	Status     string            `json:"status"`
+	Components []*checkComponent `json:"components"`
+	Errors     map[string]string `json:"errors,omitempty"`
+
+	severity float64
+}
+
+// checkComponent is a component (e.g., a disk or fan) of a collector with its decoded status and
+// state, other decoded values (e.g., vdisk policies) and readings (all other metrics of the component)
+type checkComponent struct {
+	Collector  string             `json:"collector"`
+	Labels     map[string]string  `json:"labels"`
+	Status     string             `json:"status,omitempty"`
+	State      string             `json:"state,omitempty"`
+	Attributes map[string]string  `json:"attributes,omitempty"`
+	Readings   map[string]float64 `json:"readings,omitempty"`
+
+	severity     float64
+	statusMetric string
+	// info is true if the component only has info metrics (e.g., storage_pdisk_info)
+	info bool
+}
+
+// newCommandFlags returns the flags of a command, which has all flags of the exporter and its own flags
+func newCommandFlags(command string) *flag.FlagSet {
+	commandFlags := flag.NewFlagSet("dellhw_exporter "+command, flag.ExitOnError)
+	commandFlags.AddFlagSet(flags)
+	commandFlags.SetNormalizeFunc(normalizeFlags)
+	commandFlags.SortFlags = true
+	return commandFlags
+}
+
+// loadCommandCollectors loads the enabled collectors for a command which runs them once, the
+// status are always decoded with the ordered severities
+func loadCommandCollectors() (map[string]collector.Collector, error) {
+	// The output is written to stdout, so the logs go to stderr
+	logger = setupLogger(os.Stderr)
+	collector.SetLogger(logger)
+	setupOMReportCommands()
+
+	omr := omreport.New(newCommandOptions())
+
+	enabledCollectors := append(opts.enabledCollectors, opts.additionalCollectors...)
+	return loadCollectors(omr, enabledCollectors, opts.checkCollectors)
+}
+
+// newCommandOptions returns the omreport options of the commands, the status are decoded with the
+// ordered severities and components which aren't installed (e.g., an unoccupied CPU socket) are left out
+func newCommandOptions() *omreport.Options {
+	return &omreport.Options{
+		OMReportExecutable: opts.omReportExecutable,
+		SeverityModel:      omreport.SeverityModelOrdered,
+		SkipAbsent:         true,
+	}
+}
+
+// runCheckCommand runs the collectors once and prints the report, returns the exit code
+func runCheckCommand(args []string) int {
+	checkFlags := newCommandFlags("check")
+	checkFlags.StringVar(&checkOutput, "output", "table", "Output format of the report (table or json)")
+	if err := parseFlagsAndEnvVars(checkFlags, args); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to parse flags and env vars. %v\n", err)
+		return checkExitError
+	}
+	if checkOutput != "table" && checkOutput != "json" {
+		fmt.Fprintf(os.Stderr, "invalid output %q (must be table or json)\n", checkOutput)
+		return checkExitError
+	}
+
+	collectors, err := loadCommandCollectors()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't load collectors. %v\n", err)
+		return checkExitError
+	}
+
+	report := runCheck(collectors)
+	if checkOutput == "json" {
+		err = report.writeJSON(os.Stdout)
+	} else {
+		err = report.writeTable(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write report. %v\n", err)
+		return checkExitError
+	}
+
+	return report.exitCode()
+}
+
+// checkCollector adapts a collector.Collector to a prometheus.Collector and records its error
+type checkCollector struct {
+	collector collector.Collector
+	err       error
+}
+
+// Describe implements the prometheus.Collector interface, no descriptions are sent
+// as the collectors create their descriptions dynamically.
+func (c *checkCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+// Collect implements the prometheus.Collector interface.
+func (c *checkCollector) Collect(ch chan<- prometheus.Metric) {
+	c.err = c.collector.Update(ch)
+}
+
+// runCheck runs the collectors once (in one omreport cycle) and groups their metrics by component
+func runCheck(collectors map[string]collector.Collector) *checkReport {
+	report := &checkReport{
+		Status: "Ok",
+		Errors: map[string]string{},
+	}
+
+	endCycle := omreport.StartCycle()
+	defer endCycle()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, coll := range collectors {
+		wg.Go(func() {
+			components, err := checkComponents(name, coll)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				logger.Error("collector failed", "collector", name, "error", err.Error())
+				report.Errors[name] = err.Error()
+			}
+			report.Components = append(report.Components, components...)
+		})
+	}
+	wg.Wait()
+
+	slices.SortFunc(report.Components, func(a, b *checkComponent) int {
+		if a.Collector != b.Collector {
+			return strings.Compare(a.Collector, b.Collector)
+		}
+		return strings.Compare(a.labelsString(), b.labelsString())
+	})
+
+	report.setStatus()
+	return report
+}
+
+// setStatus sets the status of the report to the worst status of the components
+func (r *checkReport) setStatus() {
+	for _, component := range r.Components {
+		if component.Status != "" && component.severity >= r.severity {
+			r.severity = component.severity
+			r.Status = component.Status
+		}
+	}
+}
+
+// checkComponents runs the collector and returns its metrics grouped by component (the labels)
+func checkComponents(name string, c collector.Collector) ([]*checkComponent, error) {
+	cc := &checkCollector{collector: c}
+	// The collectors of the commands always use the ordered severities and no state sets
+	components, err := gatherComponents(name, cc, &omreport.Options{SeverityModel: omreport.SeverityModelOrdered})
+	if err != nil {
+		return nil, err
+	}
+	return components, cc.err
+}
+
+// gatherComponents gathers the metrics of the collector and returns them grouped by component (the labels),
+// the status and state values exposed with the Options are normalized before they are decoded
+func gatherComponents(name string, c prometheus.Collector, o *omreport.Options) ([]*checkComponent, error) {
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(c); err != nil {
+		return nil, err
+	}
+	mfs, err := reg.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	components := componentSet{}
+	for _, mf := range mfs {
+		metric := strings.TrimPrefix(mf.GetName(), collector.Namespace+"_")
+		for _, m := range mf.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			var value float64
+			switch {
+			case m.GetGauge() != nil:
+				value = m.GetGauge().GetValue()
+			case m.GetCounter() != nil:
+				value = m.GetCounter().GetValue()
+			default:
+				value = m.GetUntyped().GetValue()
+			}
+
+			labels, normalized, ok := o.Normalize(metric, labels, strconv.FormatFloat(value, 'f', -1, 64))
+			if !ok {
+				continue
+			}
+			if value, err = strconv.ParseFloat(normalized, 64); err != nil {
+				return nil, err
+			}
+
+			components.add(name, labels, metric, value)
+		}
+	}
+
+	return components.sorted(), nil
+}
+
+// componentSet groups the values of the metrics by component (the labels)
+type componentSet map[string]*checkComponent
+
+// add adds the value of the metric to the component with the labels
+func (s componentSet) add(collector string, labels map[string]string, metric string, value float64) {
+	// The state is decoded from the state metric
+	if strings.HasSuffix(metric, "_state_info") {
+		return
+	}
+
+	component := &checkComponent{Collector: collector, Labels: labels}
+	key := collector + "\xff" + component.labelsString()
+	if existing, ok := s[key]; ok {
+		component = existing
+	} else {
+		s[key] = component
+	}
+	if omreport.IsInfoMetric(metric) {
+		component.info = true
+		return
+	}
+	component.add(metric, value)
+}
+
+// sorted returns the components sorted by collector and labels, the labels of info metrics are
+// merged into the attributes of their component (see mergeInfo)
+func (s componentSet) sorted() []*checkComponent {
+	s.mergeInfo()
+	components := slices.Collect(maps.Values(s))
+	slices.SortFunc(components, func(a, b *checkComponent) int {
+		if a.Collector != b.Collector {
+			return strings.Compare(a.Collector, b.Collector)
+		}
+		return strings.Compare(a.labelsString(), b.labelsString())
+	})
+	return components
+}
+
+// mergeInfo merges the labels of the info metrics (e.g., the serial number of a disk) into the attributes
+// of the component with the most labels, which are all also labels of the info metric. Info metrics
+// without a component (e.g., the BIOS version) are kept as components.
+func (s componentSet) mergeInfo() {
+	for key, info := range s {
+		if !info.info {
+			continue
+		}
+
+		var target *checkComponent
+		for _, component := range s {
+			if component.info || component.Collector != info.Collector || len(component.Labels) == 0 || len(component.Labels) >= len(info.Labels) {
+				continue
+			}
+			if target != nil && len(component.Labels) <= len(target.Labels) {
+				continue
+			}
+			subset := true
+			for name, value := range component.Labels {
+				if v, ok := info.Labels[name]; !ok || v != value {
+					subset = false
+					break
+				}
+			}
+			if subset {
+				target = component
+			}
+		}
+		if target == nil {
+			continue
+		}
+
+		if target.Attributes == nil {
+			target.Attributes = map[string]string{}
+		}
+		for name, value := range info.Labels {
+			if _, ok := target.Labels[name]; !ok {
+				target.Attributes[name] = value
+			}
+		}
+		delete(s, key)
+	}
+}
+
+// add adds the value of the metric to the component as the status, state or as a reading
+func (c *checkComponent) add(metric string, value float64) {
+	formatted := strconv.FormatFloat(value, 'f', -1, 64)
+	if name, ok := omreport.StateName(metric, formatted); ok {
+		switch {
+		case omreport.IsStatusMetric(metric):
+			// The worst status of the component is reported
+			if c.Status == "" || value > c.severity {
+				c.Status = name
+				c.severity = value
+				c.statusMetric = metric
+			}
+		case strings.HasSuffix(metric, "_state"):
+			c.State = name
+		default:
+			if c.Attributes == nil {
+				c.Attributes = map[string]string{}
+			}
+			c.Attributes[metric] = name
+		}
+		return
+	}
+
+	if c.Readings == nil {
+		c.Readings = map[string]float64{}
+	}
+	c.Readings[metric] = value
+}
+
+func (c *checkComponent) labelsString() string {
+	labels := make([]string, 0, len(c.Labels))
+	for _, name := range slices.Sorted(maps.Keys(c.Labels)) {
+		labels = append(labels, name+"="+c.Labels[name])
+	}
+	return strings.Join(labels, " ")
+}
+
+func (c *checkComponent) readingsString() string {
+	return strings.Join(c.readings(), " ")
+}
+
+// readings returns the attributes and readings, without the prefix of the status metric
+// (e.g., "reading" instead of "chassis_temps_reading")
+func (c *checkComponent) readings() []string {
+	values := make(map[string]string, len(c.Attributes)+len(c.Readings))
+	maps.Copy(values, c.Attributes)
+	for metric, value := range c.Readings {
+		values[metric] = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	prefix := strings.TrimSuffix(c.statusMetric, "_status") + "_"
+	readings := make([]string, 0, len(values))
+	for metric, value := range values {
+		name := metric
+		if c.statusMetric != "" {
+			name = strings.TrimPrefix(metric, prefix)
+		}
+		readings = append(readings, name+"="+value)
+	}
+	slices.Sort(readings)
+	return readings
+}
+
+// exitCode returns checkExitCritical if a component is Critical (or worse), checkExitError if a
+// collector failed and checkExitOK otherwise
+func (r *checkReport) exitCode() int {
+	if r.severity >= criticalSeverity {
+		return checkExitCritical
+	}
+	if len(r.Errors) > 0 {
+		return checkExitError
+	}
+	return checkExitOK
+}
+
+func (r *checkReport) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func (r *checkReport) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	collectors := []string{}
+	for _, component := range r.Components {
+		if !slices.Contains(collectors, component.Collector) {
+			collectors = append(collectors, component.Collector)
+		}
+	}
+	for name := range r.Errors {
+		if !slices.Contains(collectors, name) {
+			collectors = append(collectors, name)
+		}
+	}
+	slices.Sort(collectors)
+
+	for _, name := range collectors {
+		fmt.Fprintf(tw, "%s\n", name)
+		if err, ok := r.Errors[name]; ok {
+			fmt.Fprintf(tw, "  ERROR: %s\n", err)
+		}
+
+		header := false
+		for _, component := range r.Components {
+			if component.Collector != name {
+				continue
+			}
+			if !header {
+				fmt.Fprintf(tw, "  COMPONENT\tSTATUS\tSTATE\tREADINGS\n")
+				header = true
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", dashIfEmpty(component.labelsString()), dashIfEmpty(component.Status), dashIfEmpty(component.State), component.readingsString())
+		}
+		fmt.Fprintln(tw)
+	}
+	fmt.Fprintf(tw, "Overall status: %s\n", r.Status)
+
+	return tw.Flush()
+}
+
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
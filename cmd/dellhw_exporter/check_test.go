@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCheckCollectors(t *testing.T, backend collector.Backend, names ...string) map[string]collector.Collector {
+	t.Helper()
+
+	collectors := map[string]collector.Collector{}
+	for _, name := range names {
+		c, err := collector.Factories[name](&collector.Config{Backend: backend})
+		require.NoError(t, err)
+		collectors[name] = c
+	}
+	return collectors
+}
+
+func TestRunCheck(t *testing.T) {
+	backend := collector.NewFakeBackend()
+	backend.Values["Temps"] = []omreport.Value{
+		{Name: "chassis_temps", Value: "0", Labels: map[string]string{"component": "CPU1_Temp"}},
+		{Name: "chassis_temps_reading", Value: "34", Labels: map[string]string{"component": "CPU1_Temp"}},
+		{Name: "chassis_temps_max_warning", Value: "82", Labels: map[string]string{"component": "CPU1_Temp"}},
+	}
+	backend.Values["StorageVdisk"] = []omreport.Value{
+		{Name: "storage_vdisk_status", Value: "2", Labels: map[string]string{"vdisk": "0"}},
+		{Name: "storage_vdisk_state", Value: "2", Labels: map[string]string{"vdisk": "0"}},
+		{Name: "storage_vdisk_write_policy", Value: "1", Labels: map[string]string{"vdisk": "0"}},
+	}
+	backend.Errors["Fans"] = errors.New("omreport failed")
+
+	report := runCheck(newCheckCollectors(t, backend, "temps", "storage_vdisk", "fans"))
+	assert.Equal(t, "Non-Critical", report.Status)
+	assert.Equal(t, map[string]string{"fans": "omreport failed"}, report.Errors)
+	assert.Equal(t, checkExitError, report.exitCode())
+
+	require.Len(t, report.Components, 2)
+	vdisk := report.Components[0]
+	assert.Equal(t, "storage_vdisk", vdisk.Collector)
+	assert.Equal(t, "Non-Critical", vdisk.Status)
+	assert.Equal(t, "Degraded", vdisk.State)
+	assert.Equal(t, map[string]string{"storage_vdisk_write_policy": "Write Ahead"}, vdisk.Attributes)
+
+	temps := report.Components[1]
+	assert.Equal(t, map[string]string{"component": "CPU1_Temp"}, temps.Labels)
+	assert.Equal(t, "Ok", temps.Status)
+	assert.Equal(t, map[string]float64{"chassis_temps_reading": 34, "chassis_temps_max_warning": 82}, temps.Readings)
+	assert.Equal(t, "max_warning=82 reading=34", temps.readingsString())
+
+	table := &bytes.Buffer{}
+	require.NoError(t, report.writeTable(table))
+	assert.Contains(t, table.String(), "fans\n  ERROR: omreport failed\n")
+	assert.Contains(t, table.String(), "component=CPU1_Temp  Ok      -      max_warning=82 reading=34\n")
+	assert.Contains(t, table.String(), "Overall status: Non-Critical\n")
+
+	out := &bytes.Buffer{}
+	require.NoError(t, report.writeJSON(out))
+	decoded := checkReport{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "Non-Critical", decoded.Status)
+	assert.Len(t, decoded.Components, 2)
+}
+
+func TestRunCheckCritical(t *testing.T) {
+	backend := collector.NewFakeBackend()
+	backend.Values["Chassis"] = []omreport.Value{
+		{Name: "chassis_status", Value: "0", Labels: map[string]string{"component": "Fans"}},
+		{Name: "chassis_status", Value: "3", Labels: map[string]string{"component": "Power_Supplies"}},
+	}
+
+	report := runCheck(newCheckCollectors(t, backend, "chassis"))
+	assert.Equal(t, "Critical", report.Status)
+	assert.Equal(t, checkExitCritical, report.exitCode())
+
+	backend.Values["Chassis"][1].Value = "1"
+	report = runCheck(newCheckCollectors(t, backend, "chassis"))
+	assert.Equal(t, "Unknown", report.Status)
+	assert.Equal(t, checkExitOK, report.exitCode())
+}
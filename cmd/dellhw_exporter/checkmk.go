@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+)
+
+var checkmkKinds []string
+
+// runCheckmkCommand prints the components as Checkmk local check services
+func runCheckmkCommand(args []string) int {
+	checkmkFlags := newCommandFlags("checkmk")
+	checkmkFlags.StringSliceVar(&checkmkKinds, "kinds", []string{}, "Comma separated list of the kinds of components to print as services (all if empty), one of "+strings.Join(formatKinds(), ", "))
+	if err := parseFlagsAndEnvVars(checkmkFlags, args); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to parse flags and env vars. %v\n", err)
+		return 1
+	}
+
+	sources, err := formatSourcesOf(checkmkKinds)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	writeCheckmk(os.Stdout, newFormatBackend(), sources)
+	return 0
+}
+
+// writeCheckmk writes a local check line (`<state> "<service>" <perfdata> <text>`) per component
+// with a status or performance data, a source which couldn't be collected is reported as an
+// UNKNOWN service
+func writeCheckmk(w io.Writer, b collector.Backend, sources []formatSource) {
+	endCycle := omreport.StartCycle()
+	defer endCycle()
+
+	for _, source := range sources {
+		components, err := formatComponents(b, source)
+		if err != nil {
+			logger.Error("failed to collect components", "kind", source.kind, "error", err.Error())
+			fmt.Fprintf(w, "%d %q - failed to collect: %s\n", nagiosUnknown, "Dell HW "+source.service, strings.ReplaceAll(err.Error(), "\n", " "))
+			continue
+		}
+
+		for _, c := range components {
+			perfdata := checkmkPerfdata(c)
+			if c.Status == "" && perfdata == "-" {
+				continue
+			}
+
+			service := strings.Join(append([]string{"Dell HW", source.service}, c.identifyingLabels()...), " ")
+			fmt.Fprintf(w, "%d %q %s %s\n", nagiosState(c.severity), service, perfdata, checkmkText(c))
+		}
+	}
+}
+
+// checkmkPerfdata returns the performance data of the component ("-" if none), Checkmk only
+// supports upper thresholds
+func checkmkPerfdata(c *checkComponent) string {
+	perfdata := []string{}
+	for _, perf := range nagiosPerfdata {
+		reading, ok := c.Readings[perf.reading]
+		if !ok {
+			continue
+		}
+
+		value := perf.prefix + "=" + formatNagiosNumber(reading) + ";"
+		if warn, ok := c.Readings[perf.maxWarning]; ok {
+			value += formatNagiosNumber(warn)
+		}
+		value += ";"
+		if crit, ok := c.Readings[perf.maxCritical]; ok {
+			value += formatNagiosNumber(crit)
+		}
+		perfdata = append(perfdata, strings.TrimRight(value, ";"))
+	}
+
+	if len(perfdata) == 0 {
+		return "-"
+	}
+	return strings.Join(perfdata, "|")
+}
+
+// checkmkText returns the summary of the component, the status (and state) followed by the
+// descriptive labels, attributes and readings
+func checkmkText(c *checkComponent) string {
+	text := []string{}
+	if c.Status != "" {
+		status := c.Status
+		if c.State != "" {
+			status += " (" + c.State + ")"
+		}
+		text = append(text, status)
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Labels)) {
+		if strings.HasSuffix(name, "_name") {
+			text = append(text, name+"="+c.Labels[name])
+		}
+	}
+	text = append(text, c.readings()...)
+	return strings.Join(text, ", ")
+}
@@ -18,7 +18,9 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
 	"net/http"
 	"os"
 	"runtime"
@@ -32,6 +34,7 @@ import (
 
 	"github.com/galexrt/dellhw_exporter/collector"
 	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/galexrt/dellhw_exporter/pkg/omsa"
 	"github.com/kardianos/service"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -74,9 +77,61 @@ var (
 		[]string{"collector"},
 		nil,
 	)
+
+	omsaServiceUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.Namespace, "omsa", "service_up"),
+		"dellhw_exporter: Whether an OMSA service is up (its process is running and its socket accepts connections).",
+		[]string{"service"},
+		nil,
+	)
+
+	omsaRestartsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.Namespace, "omsa", "restarts_total"),
+		"dellhw_exporter: Number of attempted restarts of the OMSA services, by result.",
+		[]string{"result"},
+		nil,
+	)
+
+	omreportQueueWait = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: collector.Namespace,
+			Subsystem: "omreport",
+			Name:      "queue_wait_seconds",
+			Help:      "dellhw_exporter: Time an omreport command waited for a free execution slot.",
+			Buckets:   []float64{0.001, 0.01, 0.1, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"command"},
+	)
+
+	omreportParseErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: collector.Namespace,
+			Name:      "parse_errors_total",
+			Help:      "dellhw_exporter: Number of omreport output rows that couldn't be parsed, by command and reason.",
+		},
+		[]string{"command", "reason"},
+	)
 )
 
-type program struct{}
+type program struct {
+	// collector is the registered DellHWCollector, the API endpoints return the data of its last runs
+	collector *DellHWCollector
+	// omr is the OMReport the collectors use, its options are the options the values are created with
+	omr *omreport.OMReport
+
+	// omsaVersionCache, omsaVersionErr and omsaVersionTime are the result of the last run of omreport
+	// for the OMSA version shown on the status page, see omsaVersion
+	omsaVersionCache string
+	omsaVersionErr   error
+	omsaVersionTime  time.Time
+	omsaVersionMutex sync.Mutex
+
+	// readyCheckTime and readyCheckErr are the result of the last OMSA check of the readiness endpoint,
+	// see runReadyChecks
+	readyCheckTime  time.Time
+	readyCheckErr   error
+	readyCheckMutex sync.Mutex
+}
 
 // CmdLineOpts holds possible command line options/flags
 type CmdLineOpts struct {
@@ -86,8 +141,22 @@ type CmdLineOpts struct {
 
 	omReportExecutable string
 	cmdTimeout         int64
+	cmdTimeouts        map[string]int64
+	cmdMaxConcurrency  int
 
 	checkCollectors []string
+	stateSets       bool
+	severityModel   string
+	healthRulesFile string
+
+	omsaPreflight      bool
+	omsaServices       []string
+	omsaSockets        map[string]string
+	omsaRestartCommand string
+	omsaRestartBackoff time.Duration
+	omsaRestartTimeout time.Duration
+
+	statusChangesStateFile string
 
 	metricsAddr          string
 	metricsPath          string
@@ -98,6 +167,26 @@ type CmdLineOpts struct {
 
 	cachingEnabled bool
 	cacheDuration  int64
+
+	outputTextfile         string
+	outputTextfileInterval time.Duration
+
+	pushPushgatewayURL     string
+	pushRemoteWriteURL     string
+	pushOTLPEndpoint       string
+	pushOTLPProtocol       string
+	pushOTLPHeaders        map[string]string
+	pushServeHTTP          bool
+	pushHTTPConfigFile     string
+	pushInterval           time.Duration
+	pushJob                string
+	pushInstance           string
+	pushGrouping           map[string]string
+	pushGroupingServiceTag bool
+	pushTimeout            time.Duration
+	pushRetries            int
+	pushRetryBackoff       time.Duration
+	pushBufferSize         int
 }
 
 var (
@@ -116,9 +205,45 @@ type DellHWCollector struct {
 	cacheDuration  time.Duration
 	cache          []prometheus.Metric
 	cacheMutex     sync.Mutex
+
+	// preflight checks the OMSA services before the collectors are run, nil if disabled
+	preflight *omsa.Preflight
+
+	// runs contains the last run of each collector
+	runs      map[string]*collectorRun
+	runsMutex sync.RWMutex
+	// lastSuccessTime is the time of the last run of a collector which succeeded
+	lastSuccessTime time.Time
+	// omsaServices is the result of the last preflight check of the OMSA services
+	omsaServices []omsa.ServiceStatus
+}
+
+// collectorRun is the last run of a collector with its metrics
+type collectorRun struct {
+	time     time.Time
+	duration time.Duration
+	err      error
+	metrics  []prometheus.Metric
+
+	// lastErr is the error of the last failed run, which isn't necessarily this run
+	lastErr     error
+	lastErrTime time.Time
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "check", "report":
+			os.Exit(runCheckCommand(os.Args[2:]))
+		case "nagios":
+			os.Exit(runNagiosCommand(os.Args[2:]))
+		case "checkmk":
+			os.Exit(runCheckmkCommand(os.Args[2:]))
+		case "zabbix":
+			os.Exit(runZabbixCommand(os.Args[2:]))
+		}
+	}
+
 	// Service setup
 	svcConfig := &service.Config{
 		Name:        "DellOMSAExporter",
@@ -139,7 +264,7 @@ func main() {
 	}
 }
 
-func setupLogger() *slog.Logger {
+func setupLogger(w io.Writer) *slog.Logger {
 	var logLevel slog.Level
 	switch opts.logLevel {
 	case "debug", "DEBUG":
@@ -152,14 +277,14 @@ func setupLogger() *slog.Logger {
 		logLevel = slog.LevelInfo
 	}
 
-	logHandler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel})
+	logHandler := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: logLevel})
 	logger := slog.New(logHandler)
 
 	return logger
 }
 
 func (p *program) Start(s service.Service) error {
-	if err := parseFlagsAndEnvVars(); err != nil {
+	if err := parseFlagsAndEnvVars(flags, os.Args[1:]); err != nil {
 		logger.Error("failed to parse flags and env vars", "error", err.Error())
 		os.Exit(1)
 	}
@@ -182,16 +307,37 @@ func (p *program) Start(s service.Service) error {
 		os.Exit(0)
 	}
 
-	logger = setupLogger()
+	logger = setupLogger(os.Stdout)
 
 	logger.Info("starting dellhw_exporter", "version", version.Info())
 	logger.Info(fmt.Sprintf("build context: %s", version.BuildContext()))
 
-	if opts.cmdTimeout > 0 {
-		logger.Debug("setting command timeout", "cmd_timeout", opts.cmdTimeout)
-		omreport.SetCommandTimeout(opts.cmdTimeout)
-	} else {
-		logger.Warn("not setting command timeout because it is zero")
+	setupOMReportCommands()
+	// The textfile only contains the exporter's own metrics, as the node_exporter exposes the go and process metrics itself
+	var registerer prometheus.Registerer = prometheus.DefaultRegisterer
+	var gatherer prometheus.Gatherer = prometheus.DefaultGatherer
+	if opts.outputTextfile != "" && pushEnabled() {
+		logger.Error("output-textfile and the push flags can't be used together")
+		os.Exit(1)
+	}
+	if opts.outputTextfile != "" {
+		reg := prometheus.NewRegistry()
+		registerer, gatherer = reg, reg
+	}
+
+	omreport.SetQueueWaitObserver(func(command string, wait time.Duration) {
+		omreportQueueWait.WithLabelValues(command).Observe(wait.Seconds())
+	})
+	if err := registerer.Register(omreportQueueWait); err != nil {
+		logger.Error("couldn't register omreport queue wait metric", "error", err.Error())
+		os.Exit(1)
+	}
+	omreport.SetParseErrorObserver(func(command, reason string) {
+		omreportParseErrors.WithLabelValues(command, reason).Inc()
+	})
+	if err := registerer.Register(omreportParseErrors); err != nil {
+		logger.Error("couldn't register omreport parse errors metric", "error", err.Error())
+		os.Exit(1)
 	}
 
 	if opts.cachingEnabled {
@@ -200,15 +346,23 @@ func (p *program) Start(s service.Service) error {
 		logger.Info("caching is disabled by default")
 	}
 
+	severityModel, err := omreport.ParseSeverityModel(opts.severityModel)
+	if err != nil {
+		logger.Error("invalid severity model", "error", err.Error())
+		os.Exit(1)
+	}
+
 	omrOpts := &omreport.Options{
 		OMReportExecutable: opts.omReportExecutable,
+		StateSets:          opts.stateSets,
+		SeverityModel:      severityModel,
 	}
 
 	collector.SetLogger(logger)
-	collector.SetOMReport(omreport.New(omrOpts))
+	omr := omreport.New(omrOpts)
 
 	enabledCollectors := append(opts.enabledCollectors, opts.additionalCollectors...)
-	collectors, err := loadCollectors(enabledCollectors, opts.checkCollectors)
+	collectors, err := loadCollectors(omr, enabledCollectors, opts.checkCollectors)
 	if err != nil {
 		logger.Error("couldn't load collectors", "error", err.Error())
 		os.Exit(1)
@@ -221,16 +375,72 @@ func (p *program) Start(s service.Service) error {
 	}
 	logger.Info("enabled collectors", "collectors", cs)
 
-	if err = prometheus.Register(NewDellHWCollector(collectors, opts.cachingEnabled, opts.cacheDuration)); err != nil {
+	p.collector = NewDellHWCollector(collectors, opts.cachingEnabled, opts.cacheDuration)
+	if opts.omsaPreflight {
+		if runtime.GOOS != "linux" {
+			logger.Error("the OMSA services preflight check is only supported on Linux")
+			os.Exit(1)
+		}
+		p.collector.preflight = omsa.New(omsa.Options{
+			Services:       opts.omsaServices,
+			Sockets:        opts.omsaSockets,
+			RestartCommand: strings.Fields(opts.omsaRestartCommand),
+			RestartBackoff: opts.omsaRestartBackoff,
+			RestartTimeout: opts.omsaRestartTimeout,
+		})
+		logger.Info("checking the OMSA services before collecting", "services", p.collector.preflight.Services())
+	}
+	p.omr = omr
+	if err = registerer.Register(p.collector); err != nil {
 		logger.Error("couldn't register collector", "error", err.Error())
 		os.Exit(1)
 	}
 
+	if opts.outputTextfile != "" {
+		go p.runTextfile(gatherer)
+		return nil
+	}
+
+	if pushEnabled() {
+		queues, err := newPushQueues(omr)
+		if err != nil {
+			logger.Error("couldn't set up push", "error", err.Error())
+			os.Exit(1)
+		}
+		for _, c := range []prometheus.Collector{pushErrors, pushBufferedBatches} {
+			if err := registerer.Register(c); err != nil {
+				logger.Error("couldn't register push metric", "error", err.Error())
+				os.Exit(1)
+			}
+		}
+
+		go p.runPush(gatherer, queues)
+		if !opts.pushServeHTTP {
+			return nil
+		}
+	}
+
 	// non-blocking start
+	go p.runReadyChecks()
 	go p.run()
 	return nil
 }
 
+// setupOMReportCommands configures the timeout and concurrency of the omreport commands
+func setupOMReportCommands() {
+	if opts.cmdTimeout > 0 {
+		logger.Debug("setting command timeout", "cmd_timeout", opts.cmdTimeout)
+		omreport.SetCommandTimeout(opts.cmdTimeout)
+	} else {
+		logger.Warn("not setting command timeout because it is zero")
+	}
+
+	if opts.cmdMaxConcurrency > 0 {
+		logger.Debug("limiting concurrent omreport commands", "cmd_max_concurrency", opts.cmdMaxConcurrency)
+		omreport.SetMaxConcurrency(opts.cmdMaxConcurrency)
+	}
+}
+
 func (p *program) Stop(s service.Service) error {
 	// non-blocking stop
 	return nil
@@ -243,6 +453,7 @@ func NewDellHWCollector(collectors map[string]collector.Collector, cachingEnable
 		collectors:      collectors,
 		cachingEnabled:  cachingEnabled,
 		cacheDuration:   time.Duration(cacheDurationSeconds) * time.Second,
+		runs:            map[string]*collectorRun{},
 	}
 }
 
@@ -256,13 +467,46 @@ func init() {
 	flags.StringSliceVar(&opts.monitoredNics, "monitored-nics", []string{}, "Comma separated list of nics to monitor (default, empty list, is to monitor all)")
 	flags.StringVar(&opts.omReportExecutable, "collectors-omreport", getDefaultOmReportPath(), "Path to the omreport executable (based on the OS (linux or windows) default paths are used if unset)")
 	flags.Int64Var(&opts.cmdTimeout, "collectors-cmd-timeout", 15, "Command execution timeout for omreport")
+	flags.StringToInt64Var(&opts.cmdTimeouts, "collectors-cmd-timeouts", map[string]int64{}, "Per collector command execution timeout for omreport in seconds, overriding collectors-cmd-timeout. E.g., storage_pdisk=30,storage_vdisk=30")
+	flags.IntVar(&opts.cmdMaxConcurrency, "collectors-cmd-max-concurrency", 0, "Maximum number of concurrently running omreport commands (0 means unlimited)")
 	flags.StringSliceVar(&opts.checkCollectors, "collectors-check", []string{}, "Check if the specified collectors are applicable to the system and disable it otherwise. E.g., chassis_batteries ")
 	flags.MarkDeprecated("check-collectors", "Please use collectors-check instead")
+	flags.StringVar(&opts.severityModel, "collectors-severity-model", string(omreport.SeverityModelLegacy), "How the status of components is mapped to values. \"legacy\": 0 Ok, 1 Critical (and everything else), 2 Non-Critical. \"ordered\" (ordered by badness): 0 Ok, 1 Unknown, 2 Non-Critical, 3 Critical, 4 Non-Recoverable")
+	flags.StringVar(&opts.healthRulesFile, "collectors-health-rules-file", "", "Path to a YAML file mapping the subsystems of the health collector to status metrics (e.g., \"storage: [storage_pdisk_status, storage_vdisk_status]\"), the default rules are used if unset")
+	flags.StringVar(&opts.statusChangesStateFile, "collectors-status-changes-state-file", "", "Path to a file the status_changes collector persists the tracked status to, so the change counters survive restarts (not persisted if unset)")
+	flags.BoolVar(&opts.stateSets, "collectors-state-sets", false, "Expose enum-like metrics (e.g., storage_pdisk_state and the status metrics) as one series per possible state with a state label and a value of 0 or 1 (OpenMetrics StateSet style)")
+
+	flags.BoolVar(&opts.omsaPreflight, "omsa-preflight", false, "Check if the OMSA services are up before running the collectors, the collectors are skipped while they are down (only supported on Linux)")
+	flags.StringSliceVar(&opts.omsaServices, "omsa-services", omsa.DefaultServices, "Comma separated list of the processes of the OMSA services which must be running")
+	flags.StringToStringVar(&opts.omsaSockets, "omsa-sockets", map[string]string{}, "Unix sockets of the OMSA services which must accept connections, by service, e.g., dsm_sa_datamgrd=/path/to/socket")
+	flags.StringVar(&opts.omsaRestartCommand, "omsa-restart-command", "", "Command run to restart the OMSA services if one is down (e.g., \"systemctl restart dataeng\"), split at spaces and not run through a shell, no restart is attempted if unset")
+	flags.DurationVar(&opts.omsaRestartBackoff, "omsa-restart-backoff", 5*time.Minute, "Minimum time between two restarts of the OMSA services")
+	flags.DurationVar(&opts.omsaRestartTimeout, "omsa-restart-timeout", 2*time.Minute, "Time after which the omsa-restart-command is killed")
 
 	flags.StringVar(&opts.metricsAddr, "web-listen-address", ":9137", "The address to listen on for HTTP requests")
 	flags.StringVar(&opts.metricsPath, "web-telemetry-path", "/metrics", "Path the metrics will be exposed under")
 	flags.StringVar(&opts.webConfigPath, "web-config-file", "", "[EXPERIMENTAL] Path to configuration file that can enable TLS or authentication.")
 
+	flags.StringVar(&opts.outputTextfile, "output-textfile", "", "Write the metrics to this file (e.g., for the node_exporter textfile collector, the file name must end with .prom) instead of serving them over HTTP")
+	flags.DurationVar(&opts.outputTextfileInterval, "output-textfile-interval", 0, "Interval in which the output-textfile is written, the file is written once and the exporter exits if zero")
+
+	flags.StringVar(&opts.pushPushgatewayURL, "push-pushgateway-url", "", "Push the metrics to this Pushgateway (e.g., http://pushgateway:9091) instead of serving them over HTTP")
+	flags.StringVar(&opts.pushRemoteWriteURL, "push-remote-write-url", "", "Send the metrics as Prometheus remote write requests to this URL (e.g., http://prometheus:9090/api/v1/write) instead of serving them over HTTP")
+	flags.StringVar(&opts.pushOTLPEndpoint, "push-otlp-endpoint", "", "Export the metrics over OTLP to this endpoint (e.g., http://otel-collector:4318/v1/metrics for http/protobuf, http://otel-collector:4317 for grpc) instead of serving them over HTTP")
+	flags.StringVar(&opts.pushOTLPProtocol, "push-otlp-protocol", otlpProtocolHTTP, "Protocol of the push-otlp-endpoint, \"http/protobuf\" or \"grpc\"")
+	flags.StringToStringVar(&opts.pushOTLPHeaders, "push-otlp-headers", map[string]string{}, "Headers of the OTLP requests (e.g., for authentication), e.g., X-Scope-OrgID=tenant1")
+	flags.BoolVar(&opts.pushServeHTTP, "push-serve-http", false, "Serve the metrics over HTTP (web-listen-address) in addition to pushing them")
+	flags.StringVar(&opts.pushHTTPConfigFile, "push-http-config-file", "", "Path to a Prometheus HTTP client configuration file (e.g., basic_auth and tls_config) for the push requests")
+	flags.DurationVar(&opts.pushInterval, "push-interval", time.Minute, "Interval in which the metrics are pushed, the metrics are pushed once and the exporter exits if zero")
+	flags.StringVar(&opts.pushJob, "push-job", "dellhw_exporter", "Job label of the pushed metrics")
+	flags.StringVar(&opts.pushInstance, "push-instance", "", "Instance label of the pushed metrics (grouping label for the Pushgateway), the hostname is used if unset")
+	flags.StringToStringVar(&opts.pushGrouping, "push-grouping", map[string]string{}, "Additional labels of the pushed metrics (grouping labels for the Pushgateway), e.g., datacenter=dc1,service=storage")
+	flags.BoolVar(&opts.pushGroupingServiceTag, "push-grouping-service-tag", false, "Add the chassis service tag as service_tag label to the pushed metrics (grouping label for the Pushgateway), e.g., to keep the metrics of hosts with the same push-instance apart")
+	flags.DurationVar(&opts.pushTimeout, "push-timeout", 10*time.Second, "Timeout of a push request")
+	flags.IntVar(&opts.pushRetries, "push-retries", 3, "Number of retries of a failed push, with an exponential backoff")
+	flags.DurationVar(&opts.pushRetryBackoff, "push-retry-backoff", time.Second, "Backoff before the first retry of a failed push, doubled for each further retry (up to 30s)")
+	flags.IntVar(&opts.pushBufferSize, "push-buffer-size", 60, "Number of gathered batches of metrics kept while the remote write endpoint can't be reached, the oldest batches are dropped first")
+
 	flags.BoolVar(&opts.cachingEnabled, "cache-enabled", false, "Enable metrics caching to reduce load")
 	flags.Int64Var(&opts.cacheDuration, "cache-duration", 20, "Cache duration in seconds")
 
@@ -285,6 +529,8 @@ func normalizeFlags(f *flag.FlagSet, name string) flag.NormalizedName {
 		name = "collectors-omreport"
 	case "collectors.cmd-timeout":
 		name = "collectors-cmd-timeout"
+	case "output.textfile":
+		name = "output-textfile"
 	}
 	return flag.NormalizedName(name)
 }
@@ -295,7 +541,7 @@ func flagNameFromEnvName(s string) string {
 	return s
 }
 
-func parseFlagsAndEnvVars() error {
+func parseFlagsAndEnvVars(flags *flag.FlagSet, args []string) error {
 	for _, v := range os.Environ() {
 		vals := strings.SplitN(v, "=", 2)
 
@@ -314,13 +560,17 @@ func parseFlagsAndEnvVars() error {
 		}
 	}
 
-	return flags.Parse(os.Args[1:])
+	return flags.Parse(args)
 }
 
 // Describe implements the prometheus.Collector interface.
 func (n *DellHWCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- scrapeDurationDesc
 	ch <- scrapeSuccessDesc
+	if n.preflight != nil {
+		ch <- omsaServiceUpDesc
+		ch <- omsaRestartsDesc
+	}
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -358,10 +608,41 @@ func (n *DellHWCollector) Collect(outgoingCh chan<- prometheus.Metric) {
 		logger.Debug("finished pushing metrics from metricsCh to outgoingCh")
 	})
 
+	// The collectors would only fail (or time out) while the OMSA services are down
+	if err := n.checkOMSA(metricsCh); err != nil {
+		logger.Error("skipping collectors", "error", err.Error())
+		for name := range n.collectors {
+			n.skip(name, err)
+		}
+	} else {
+		n.runCollectors(metricsCh)
+	}
+
+	now := time.Now()
+	n.runsMutex.Lock()
+	n.lastCollectTime = now
+	n.runsMutex.Unlock()
+	logger.Debug(fmt.Sprintf("updated lastCollectTime to %s", now.String()))
+
+	close(metricsCh)
+
+	logger.Debug("waiting for outgoing Adapter")
+	wgOutgoing.Wait()
+	logger.Debug("finished waiting for outgoing Adapter")
+}
+
+// runCollectors runs the collectors of a collection cycle, the derived collectors last
+func (n *DellHWCollector) runCollectors(metricsCh chan<- prometheus.Metric) {
+	// Each distinct omreport command is only run once per collection cycle
+	endCycle := omreport.StartCycle()
+
 	var wgCollection sync.WaitGroup
 	for name, coll := range n.collectors {
+		if _, ok := coll.(collector.Derived); ok {
+			continue
+		}
 		wgCollection.Go(func() {
-			execute(name, coll, metricsCh)
+			n.execute(name, coll, metricsCh)
 		})
 	}
 
@@ -369,20 +650,80 @@ func (n *DellHWCollector) Collect(outgoingCh chan<- prometheus.Metric) {
 	wgCollection.Wait()
 	logger.Debug("finished waiting for collectors")
 
-	n.lastCollectTime = time.Now()
-	logger.Debug(fmt.Sprintf("updated lastCollectTime to %s", n.lastCollectTime.String()))
+	// Derived collectors run after the other collectors, so they can reuse the omreport outputs of the cycle
+	for name, coll := range n.collectors {
+		if _, ok := coll.(collector.Derived); ok {
+			wgCollection.Go(func() {
+				n.execute(name, coll, metricsCh)
+			})
+		}
+	}
+	wgCollection.Wait()
 
-	close(metricsCh)
+	endCycle()
+}
 
-	logger.Debug("waiting for outgoing Adapter")
-	wgOutgoing.Wait()
-	logger.Debug("finished waiting for outgoing Adapter")
+// checkOMSA checks the OMSA services, restarts them in the background if they are down and a restart
+// command is set and sends the service metrics to ch. It returns an error wrapping omsa.ErrServicesDown
+// if they are down, the collection doesn't wait for the restart.
+func (n *DellHWCollector) checkOMSA(ch chan<- prometheus.Metric) error {
+	if n.preflight == nil {
+		return nil
+	}
+
+	statuses := n.preflight.Check()
+	err := omsa.Err(statuses)
+	n.runsMutex.Lock()
+	n.omsaServices = statuses
+	n.runsMutex.Unlock()
+	if err != nil && n.preflight.CanRestart() {
+		reason := err.Error()
+		started := n.preflight.RestartAsync(func(restartErr error) {
+			if restartErr != nil {
+				logger.Error("failed to restart the OMSA services", "error", restartErr.Error())
+				return
+			}
+			logger.Warn("restarted the OMSA services", "reason", reason)
+		})
+		if started {
+			logger.Info("restarting the OMSA services", "reason", reason)
+		}
+	}
+
+	for _, s := range statuses {
+		up := 0.0
+		if s.Up {
+			up = 1
+		}
+		ch <- prometheus.MustNewConstMetric(omsaServiceUpDesc, prometheus.GaugeValue, up, s.Name)
+	}
+	if n.preflight.CanRestart() {
+		for result, count := range n.preflight.Restarts() {
+			ch <- prometheus.MustNewConstMetric(omsaRestartsDesc, prometheus.CounterValue, count, result)
+		}
+	}
+	return err
 }
 
-func execute(name string, c collector.Collector, ch chan<- prometheus.Metric) {
-	begin := time.Now()
-	err := c.Update(ch)
-	duration := time.Since(begin)
+// execute runs the collector, sends its metrics and the scrape metrics to ch and records the run
+func (n *DellHWCollector) execute(name string, c collector.Collector, ch chan<- prometheus.Metric) {
+	run := &collectorRun{time: time.Now()}
+	updateCh := make(chan prometheus.Metric)
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for metric := range updateCh {
+			run.metrics = append(run.metrics, metric)
+			// The inventory only metrics are kept for the API, but not exposed
+			if !collector.InventoryOnly(metric) {
+				ch <- metric
+			}
+		}
+	})
+
+	err := c.Update(updateCh)
+	close(updateCh)
+	wg.Wait()
+	duration := time.Since(run.time)
 	var success float64
 
 	if err != nil {
@@ -394,16 +735,92 @@ func execute(name string, c collector.Collector, ch chan<- prometheus.Metric) {
 	}
 	ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, duration.Seconds(), name)
 	ch <- prometheus.MustNewConstMetric(scrapeSuccessDesc, prometheus.GaugeValue, success, name)
+
+	run.duration, run.err = duration, err
+	n.record(name, run)
+}
+
+// skip records a run of the collector which wasn't run because of err. No scrape metrics are sent, so
+// a skipped collector isn't mistaken for a failed one (the dell_hw_omsa_service_up metrics tell why).
+func (n *DellHWCollector) skip(name string, err error) {
+	n.record(name, &collectorRun{time: time.Now(), err: err})
+}
+
+// record stores the run as the last run of the collector
+func (n *DellHWCollector) record(name string, run *collectorRun) {
+	n.runsMutex.Lock()
+	defer n.runsMutex.Unlock()
+	if run.err != nil {
+		run.lastErr, run.lastErrTime = run.err, run.time
+	} else {
+		if prev, ok := n.runs[name]; ok {
+			run.lastErr, run.lastErrTime = prev.lastErr, prev.lastErrTime
+		}
+		if run.time.After(n.lastSuccessTime) {
+			n.lastSuccessTime = run.time
+		}
+	}
+	n.runs[name] = run
+}
+
+// lastRuns returns the last run of the collectors which have run
+func (n *DellHWCollector) lastRuns() map[string]*collectorRun {
+	n.runsMutex.RLock()
+	defer n.runsMutex.RUnlock()
+	return maps.Clone(n.runs)
 }
 
-func getCollectorConfig() *collector.Config {
+// lastOMSAServices returns the result of the last preflight check of the OMSA services, nil if they
+// weren't checked yet
+func (n *DellHWCollector) lastOMSAServices() []omsa.ServiceStatus {
+	n.runsMutex.RLock()
+	defer n.runsMutex.RUnlock()
+	return n.omsaServices
+}
+
+// lastSuccess returns the time of the last run of a collector which succeeded, zero if none succeeded yet
+func (n *DellHWCollector) lastSuccess() time.Time {
+	n.runsMutex.RLock()
+	defer n.runsMutex.RUnlock()
+	return n.lastSuccessTime
+}
+
+// lastCollect returns the time of the last completed collection, zero if nothing was collected yet
+func (n *DellHWCollector) lastCollect() time.Time {
+	n.runsMutex.RLock()
+	defer n.runsMutex.RUnlock()
+	if n.lastCollectTime.Equal(time.Unix(0, 0)) {
+		return time.Time{}
+	}
+	return n.lastCollectTime
+}
+
+func getCollectorConfig(backend collector.Backend) *collector.Config {
 	return &collector.Config{
+		Backend:       backend,
 		MonitoredNICs: opts.monitoredNics,
 	}
 }
 
-func loadCollectors(list []string, check []string) (map[string]collector.Collector, error) {
-	cfg := getCollectorConfig()
+func loadCollectors(omr *omreport.OMReport, list []string, check []string) (map[string]collector.Collector, error) {
+	cfg := getCollectorConfig(omr)
+
+	// The collectors derived from the status always use the ordered severities and no state sets,
+	// components which aren't installed (e.g., an unoccupied CPU socket) don't affect the health
+	statusOmr := omr.WithOptions(func(o *omreport.Options) {
+		o.SeverityModel = omreport.SeverityModelOrdered
+		o.StateSets = false
+		o.SkipAbsent = true
+	})
+	cfg.StatusBackend = statusOmr
+	cfg.StatusChangesStateFile = opts.statusChangesStateFile
+	if opts.healthRulesFile != "" {
+		rules, err := collector.LoadHealthRules(opts.healthRulesFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.HealthRules = rules
+	}
 
 	collectors := map[string]collector.Collector{}
 	var c collector.Collector
@@ -414,7 +831,13 @@ func loadCollectors(list []string, check []string) (map[string]collector.Collect
 			return nil, fmt.Errorf("collector %q not available", name)
 		}
 
-		c, err = fn(cfg)
+		ccfg := *cfg
+		if timeout, ok := opts.cmdTimeouts[name]; ok && timeout > 0 {
+			ccfg.Backend = omr.WithCommandTimeout(time.Duration(timeout) * time.Second)
+			ccfg.StatusBackend = statusOmr.WithCommandTimeout(time.Duration(timeout) * time.Second)
+		}
+
+		c, err = fn(&ccfg)
 		if err != nil {
 			return nil, err
 		}
@@ -423,8 +846,8 @@ func loadCollectors(list []string, check []string) (map[string]collector.Collect
 			if cc, ok := c.(collector.IsAvailable); ok {
 				if !cc.IsAvailable() {
 					logger.Warn("disabling collector because it is not applicable to the system", "collector", name)
+					continue
 				}
-				continue
 			}
 			logger.Debug("collector is applicable to the system", "collector", name)
 		}
@@ -454,16 +877,11 @@ func (p *program) run() {
 	http.HandleFunc(opts.metricsPath, func(w http.ResponseWriter, r *http.Request) {
 		handler.ServeHTTP(w, r)
 	})
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		w.Write([]byte(`<!DOCTYPE html>
-<html>
-	<head><title>DellHW Exporter</title></head>
-	<body>
-		<h1>DellHW Exporter</h1>
-		<p><a href="` + opts.metricsPath + `">Metrics</a></p>
-	</body>
-</html>`))
-	})
+	http.HandleFunc(apiInventoryPath, p.handleInventory)
+	http.HandleFunc(apiStatusPath, p.handleStatus)
+	http.HandleFunc(healthyPath, p.handleHealthy)
+	http.HandleFunc(readyPath, p.handleReady)
+	http.HandleFunc("/", p.handleStatusPage)
 
 	server := &http.Server{}
 	if err := web.ListenAndServe(server, &web.FlagConfig{WebListenAddresses: &[]string{opts.metricsAddr}, WebConfigFile: &opts.webConfigPath}, logger); err != nil {
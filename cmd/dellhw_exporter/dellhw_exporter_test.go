@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/galexrt/dellhw_exporter/pkg/omsa"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMetricDesc = prometheus.NewDesc("dell_hw_test_value", "Test value.", []string{"collector"}, nil)
+
+// testCollector counts its updates and emits one metric or returns an error
+type testCollector struct {
+	name    string
+	err     error
+	updates atomic.Int32
+	// barrier if set, Update waits for the barrier to be released
+	barrier *sync.WaitGroup
+}
+
+func (c *testCollector) Update(ch chan<- prometheus.Metric) error {
+	c.updates.Add(1)
+	if c.barrier != nil {
+		c.barrier.Done()
+		c.barrier.Wait()
+	}
+	if c.err != nil {
+		return c.err
+	}
+
+	ch <- prometheus.MustNewConstMetric(testMetricDesc, prometheus.GaugeValue, 1, c.name)
+	return nil
+}
+
+// collectAndCount gathers the collector through a non-pedantic registry (like the default registry),
+// as the DellHWCollector only describes the scrape metrics and not the metrics of the collectors.
+func collectAndCount(t *testing.T, c prometheus.Collector, metricNames ...string) int {
+	t.Helper()
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+	count, err := testutil.GatherAndCount(reg, metricNames...)
+	assert.NoError(t, err)
+	return count
+}
+
+func TestMain(m *testing.M) {
+	logger = slog.New(slog.DiscardHandler)
+	os.Exit(m.Run())
+}
+
+func TestCollectExecuteMetrics(t *testing.T) {
+	ok := &testCollector{name: "ok"}
+	failing := &testCollector{name: "failing", err: errors.New("omreport failed")}
+
+	c := NewDellHWCollector(map[string]collector.Collector{
+		"ok":      ok,
+		"failing": failing,
+	}, false, 0)
+
+	expected := `
+# HELP dell_hw_scrape_collector_success dellhw_exporter: Whether a collector succeeded.
+# TYPE dell_hw_scrape_collector_success gauge
+dell_hw_scrape_collector_success{collector="failing"} 0
+dell_hw_scrape_collector_success{collector="ok"} 1
+# HELP dell_hw_test_value Test value.
+# TYPE dell_hw_test_value gauge
+dell_hw_test_value{collector="ok"} 1
+`
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"dell_hw_scrape_collector_success", "dell_hw_test_value"))
+
+	// Every collector has a duration metric
+	assert.Equal(t, 2, collectAndCount(t, c, "dell_hw_scrape_collector_duration_seconds"))
+}
+
+func TestCollectCache(t *testing.T) {
+	tc := &testCollector{name: "ok"}
+	c := NewDellHWCollector(map[string]collector.Collector{"ok": tc}, true, 60)
+
+	first := collectAndCount(t, c)
+	second := collectAndCount(t, c)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 3, second)
+	assert.Equal(t, int32(1), tc.updates.Load())
+
+	// Expire the cache
+	c.lastCollectTime = time.Now().Add(-2 * time.Minute)
+	assert.Equal(t, 3, collectAndCount(t, c))
+	assert.Equal(t, int32(2), tc.updates.Load())
+}
+
+func TestCollectWithoutCache(t *testing.T) {
+	tc := &testCollector{name: "ok"}
+	c := NewDellHWCollector(map[string]collector.Collector{"ok": tc}, false, 60)
+
+	collectAndCount(t, c)
+	collectAndCount(t, c)
+	assert.Equal(t, int32(2), tc.updates.Load())
+}
+
+func TestCollectConcurrently(t *testing.T) {
+	// Each collector waits for all other collectors to be running,
+	// which only completes when the collectors are run concurrently
+	barrier := &sync.WaitGroup{}
+	collectors := map[string]collector.Collector{}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		barrier.Add(1)
+		collectors[name] = &testCollector{name: name, barrier: barrier}
+	}
+	c := NewDellHWCollector(collectors, false, 0)
+
+	done := make(chan int)
+	go func() {
+		done <- collectAndCount(t, c, "dell_hw_test_value")
+	}()
+
+	select {
+	case count := <-done:
+		assert.Equal(t, 4, count)
+	case <-time.After(5 * time.Second):
+		t.Fatal("collectors haven't been run concurrently")
+	}
+}
+
+// derivedTestCollector records whether the other collectors had finished when it was run
+type derivedTestCollector struct {
+	others         []*testCollector
+	othersFinished bool
+}
+
+func (c *derivedTestCollector) Derived() {}
+
+func (c *derivedTestCollector) Update(ch chan<- prometheus.Metric) error {
+	c.othersFinished = true
+	for _, other := range c.others {
+		if other.updates.Load() == 0 {
+			c.othersFinished = false
+		}
+	}
+	return nil
+}
+
+func TestCollectDerivedLast(t *testing.T) {
+	collectors := map[string]collector.Collector{}
+	derived := &derivedTestCollector{}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		tc := &testCollector{name: name}
+		derived.others = append(derived.others, tc)
+		collectors[name] = tc
+	}
+	collectors["derived"] = derived
+
+	c := NewDellHWCollector(collectors, false, 0)
+	assert.Equal(t, 5, collectAndCount(t, c, "dell_hw_scrape_collector_success"))
+	assert.True(t, derived.othersFinished)
+}
+
+func TestLoadCollectorsCheck(t *testing.T) {
+	fixtures := omreport.NewFixtureReader("../../collector/testdata/omreport")
+	omr := &omreport.OMReport{
+		Options: &omreport.Options{},
+		Reader:  fixtures,
+	}
+
+	// Checked collectors are loaded if they are applicable
+	collectors, err := loadCollectors(omr, []string{"chassis", "chassis_batteries"}, []string{"chassis_batteries"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"chassis", "chassis_batteries"}, slices.Collect(maps.Keys(collectors)))
+
+	omr.Reader = func(f func(omreport.Output), mode omreport.ReaderMode, cmd string, args ...string) error {
+		if slices.Equal(args, []string{"chassis", "batteries"}) {
+			return errors.New("No battery probes found on this system")
+		}
+		return fixtures(f, mode, cmd, args...)
+	}
+	collectors, err = loadCollectors(omr, []string{"chassis", "chassis_batteries"}, []string{"chassis_batteries"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"chassis"}, slices.Collect(maps.Keys(collectors)))
+
+	// Collectors which aren't checked are always loaded
+	collectors, err = loadCollectors(omr, []string{"chassis", "chassis_batteries"}, nil)
+	require.NoError(t, err)
+	assert.Len(t, collectors, 2)
+}
+
+func TestCollectOMSAPreflight(t *testing.T) {
+	procDir := t.TempDir()
+	tc := &testCollector{name: "ok"}
+	c := NewDellHWCollector(map[string]collector.Collector{"ok": tc}, false, 0)
+	c.preflight = omsa.New(omsa.Options{Services: []string{"dsm_sa_datamgrd"}, ProcDir: procDir})
+
+	// The collectors are skipped while the services are down, without scrape metrics
+	expected := `
+# HELP dell_hw_omsa_service_up dellhw_exporter: Whether an OMSA service is up (its process is running and its socket accepts connections).
+# TYPE dell_hw_omsa_service_up gauge
+dell_hw_omsa_service_up{service="dsm_sa_datamgrd"} 0
+`
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"dell_hw_omsa_service_up", "dell_hw_scrape_collector_success", "dell_hw_scrape_collector_duration_seconds", "dell_hw_test_value"))
+	assert.Equal(t, int32(0), tc.updates.Load())
+	err := c.lastRuns()["ok"].err
+	assert.ErrorIs(t, err, omsa.ErrServicesDown)
+	assert.EqualError(t, err, "OMSA services are down: dsm_sa_datamgrd not running")
+
+	// The restart command "starts" the service once it is released, the scrape doesn't wait for it
+	release := filepath.Join(t.TempDir(), "release")
+	c.preflight = omsa.New(omsa.Options{
+		Services: []string{"dsm_sa_datamgrd"},
+		ProcDir:  procDir,
+		RestartCommand: []string{"sh", "-c", `while [ ! -e "$1" ]; do sleep 0.01; done; mkdir "$0/100" && echo dsm_sa_datamgrd > "$0/100/comm"`,
+			procDir, release},
+		RestartTimeout: 10 * time.Second,
+	})
+	expected = `
+# HELP dell_hw_omsa_restarts_total dellhw_exporter: Number of attempted restarts of the OMSA services, by result.
+# TYPE dell_hw_omsa_restarts_total counter
+dell_hw_omsa_restarts_total{result="failure"} 0
+dell_hw_omsa_restarts_total{result="success"} 0
+# HELP dell_hw_omsa_service_up dellhw_exporter: Whether an OMSA service is up (its process is running and its socket accepts connections).
+# TYPE dell_hw_omsa_service_up gauge
+dell_hw_omsa_service_up{service="dsm_sa_datamgrd"} 0
+`
+	reg = prometheus.NewRegistry()
+	reg.MustRegister(c)
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"dell_hw_omsa_restarts_total", "dell_hw_omsa_service_up", "dell_hw_scrape_collector_success"))
+	assert.Equal(t, int32(0), tc.updates.Load())
+
+	require.NoError(t, os.WriteFile(release, nil, 0o644))
+	require.Eventually(t, func() bool {
+		return c.preflight.Restarts()["success"] == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	expected = `
+# HELP dell_hw_omsa_restarts_total dellhw_exporter: Number of attempted restarts of the OMSA services, by result.
+# TYPE dell_hw_omsa_restarts_total counter
+dell_hw_omsa_restarts_total{result="failure"} 0
+dell_hw_omsa_restarts_total{result="success"} 1
+# HELP dell_hw_omsa_service_up dellhw_exporter: Whether an OMSA service is up (its process is running and its socket accepts connections).
+# TYPE dell_hw_omsa_service_up gauge
+dell_hw_omsa_service_up{service="dsm_sa_datamgrd"} 1
+# HELP dell_hw_scrape_collector_success dellhw_exporter: Whether a collector succeeded.
+# TYPE dell_hw_scrape_collector_success gauge
+dell_hw_scrape_collector_success{collector="ok"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"dell_hw_omsa_restarts_total", "dell_hw_omsa_service_up", "dell_hw_scrape_collector_success"))
+	assert.Equal(t, int32(1), tc.updates.Load())
+	assert.NoError(t, c.lastRuns()["ok"].err)
+	// The last error is kept
+	assert.ErrorIs(t, c.lastRuns()["ok"].lastErr, omsa.ErrServicesDown)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+)
+
+// formatSource is a kind of components (e.g., disks) and the backend method returning their values
+type formatSource struct {
+	kind string
+	// service is the name of the kind in the Checkmk services
+	service string
+	values  func(b collector.Backend) ([]omreport.Value, error)
+}
+
+// formatSources are the kinds of components of the checkmk and zabbix commands
+var formatSources = []formatSource{
+	{"chassis", "Chassis", collector.Backend.Chassis},
+	{"system", "System", collector.Backend.System},
+	{"processors", "Processor", collector.Backend.Processors},
+	{"memory", "Memory", collector.Backend.Memory},
+	{"fans", "Fan", collector.Backend.Fans},
+	{"temps", "Temp", collector.Backend.Temps},
+	{"volts", "Volts", collector.Backend.Volts},
+	{"psus", "PSU", collector.Backend.Ps},
+	{"power", "Power", collector.Backend.PsAmpsSysboardPwr},
+	{"controllers", "Controller", collector.Backend.StorageController},
+	{"batteries", "Controller Battery", collector.Backend.StorageBattery},
+	{"enclosures", "Enclosure", collector.Backend.StorageEnclosure},
+	{"disks", "Disk", collector.AllStoragePdisks},
+	{"vdisks", "VDisk", collector.Backend.StorageVdisk},
+}
+
+// formatKinds returns the kinds of the formatSources
+func formatKinds() []string {
+	kinds := make([]string, 0, len(formatSources))
+	for _, source := range formatSources {
+		kinds = append(kinds, source.kind)
+	}
+	return kinds
+}
+
+// formatSourcesOf returns the sources of the kinds, all sources if no kinds are given
+func formatSourcesOf(kinds []string) ([]formatSource, error) {
+	if len(kinds) == 0 {
+		return formatSources, nil
+	}
+
+	sources := []formatSource{}
+	for _, kind := range kinds {
+		i := slices.IndexFunc(formatSources, func(s formatSource) bool { return s.kind == kind })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown kind %q (must be one of %s)", kind, strings.Join(formatKinds(), ", "))
+		}
+		sources = append(sources, formatSources[i])
+	}
+	return sources, nil
+}
+
+// newFormatBackend returns the backend of the checkmk and zabbix commands, with the options of
+// the other commands (see newCommandOptions)
+func newFormatBackend() collector.Backend {
+	// The output is written to stdout, so the logs go to stderr
+	logger = setupLogger(os.Stderr)
+	collector.SetLogger(logger)
+	setupOMReportCommands()
+
+	return omreport.New(newCommandOptions())
+}
+
+// formatComponents returns the components of the source, grouped by their labels
+func formatComponents(b collector.Backend, source formatSource) ([]*checkComponent, error) {
+	values, err := source.values(b)
+	if err != nil {
+		return nil, err
+	}
+
+	components := componentSet{}
+	for _, value := range values {
+		f, err := strconv.ParseFloat(value.Value, 64)
+		if err != nil {
+			return nil, err
+		}
+		components.add(source.kind, value.Labels, value.Name, f)
+	}
+	return components.sorted(), nil
+}
+
+// identifyingLabels returns the values of the labels identifying the component, labels with
+// names (e.g., controller_name) are descriptive and not used
+func (c *checkComponent) identifyingLabels() []string {
+	values := []string{}
+	for _, name := range slices.Sorted(maps.Keys(c.Labels)) {
+		if !strings.HasSuffix(name, "_name") {
+			values = append(values, c.Labels[name])
+		}
+	}
+	return values
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatSourcesOf(t *testing.T) {
+	sources, err := formatSourcesOf(nil)
+	require.NoError(t, err)
+	assert.Len(t, sources, len(formatSources))
+
+	sources, err = formatSourcesOf([]string{"disks", "fans"})
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	assert.Equal(t, "disks", sources[0].kind)
+	assert.Equal(t, "fans", sources[1].kind)
+
+	_, err = formatSourcesOf([]string{"nics"})
+	assert.ErrorContains(t, err, `unknown kind "nics"`)
+}
+
+func TestWriteCheckmk(t *testing.T) {
+	backend := newNagiosBackend()
+	backend.Values["StorageVdisk"] = []omreport.Value{
+		{Name: "storage_vdisk_status", Value: "0", Labels: map[string]string{"controller_name": "PERC H730 Mini", "vdisk": "0", "vdisk_name": "GenericR5_0"}},
+		{Name: "storage_vdisk_state", Value: "1", Labels: map[string]string{"controller_name": "PERC H730 Mini", "vdisk": "0", "vdisk_name": "GenericR5_0"}},
+		{Name: "storage_vdisk_write_policy", Value: "1", Labels: map[string]string{"controller_name": "PERC H730 Mini", "vdisk": "0", "vdisk_name": "GenericR5_0"}},
+	}
+	backend.Errors["Fans"] = errors.New("omreport failed")
+
+	sources, err := formatSourcesOf([]string{"temps", "fans", "power", "controllers", "disks", "vdisks"})
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	writeCheckmk(out, backend, sources)
+	assert.Equal(t, `0 "Dell HW Temp CPU1_Temp" temp=34;82;87 Ok, max_failure=87, max_warning=82, min_failure=3, min_warning=8, reading=34
+3 "Dell HW Fan" - failed to collect: omreport failed
+0 "Dell HW Power" power=84;896;980 chassis_power_fail_level=980, chassis_power_reading=84, chassis_power_warn_level=896
+0 "Dell HW Controller 0" - Ok
+0 "Dell HW Controller 1" - Ok
+0 "Dell HW Disk 0 0_1_0" - Ok
+2 "Dell HW Disk 1 0_1_0" - Critical (Failed)
+0 "Dell HW VDisk 0" - Ok (Ready), controller_name=PERC H730 Mini, vdisk_name=GenericR5_0, write_policy=Write Ahead
+`, out.String())
+}
+
+func TestWriteCheckmkFixtures(t *testing.T) {
+	// The fixture host is healthy, its unoccupied CPU socket isn't an unknown service
+	backend := &omreport.OMReport{
+		Options: newCommandOptions(),
+		Reader:  omreport.NewFixtureReader("../../collector/testdata/omreport"),
+	}
+
+	out := &bytes.Buffer{}
+	writeCheckmk(out, backend, formatSources)
+	assert.NotContains(t, out.String(), "CPU2")
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		assert.True(t, strings.HasPrefix(line, "0 "), line)
+	}
+	assert.Contains(t, out.String(), `0 "Dell HW Processor CPU1" - Ok`)
+}
+
+func TestWriteZabbix(t *testing.T) {
+	backend := newNagiosBackend()
+
+	sources, err := formatSourcesOf([]string{"disks"})
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, writeZabbix(out, backend, sources[0]))
+	assert.JSONEq(t, `[
+		{"{#CONTROLLER}": "0", "{#DISK}": "0_1_0", "status": "Ok"},
+		{"{#CONTROLLER}": "1", "{#DISK}": "0_1_0", "status": "Critical", "state": "Failed"}
+	]`, out.String())
+
+	sources, err = formatSourcesOf([]string{"temps"})
+	require.NoError(t, err)
+
+	out.Reset()
+	require.NoError(t, writeZabbix(out, backend, sources[0]))
+	assert.JSONEq(t, `[
+		{"{#COMPONENT}": "CPU1_Temp", "status": "Ok", "values": {
+			"chassis_temps_reading": 34,
+			"chassis_temps_min_warning": 8,
+			"chassis_temps_max_warning": 82,
+			"chassis_temps_min_failure": 3,
+			"chassis_temps_max_failure": 87
+		}}
+	]`, out.String())
+
+	backend.Errors["StorageController"] = errors.New("omreport failed")
+	sources, err = formatSourcesOf([]string{"disks"})
+	require.NoError(t, err)
+	assert.ErrorContains(t, writeZabbix(out, backend, sources[0]), "omreport failed")
+}
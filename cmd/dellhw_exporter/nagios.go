@@ -0,0 +1,305 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Nagios plugin states, which are the exit codes of the plugin
+const (
+	nagiosOK       = 0
+	nagiosWarning  = 1
+	nagiosCritical = 2
+	nagiosUnknown  = 3
+)
+
+var nagiosStateNames = map[int]string{
+	nagiosOK:       "OK",
+	nagiosWarning:  "WARNING",
+	nagiosCritical: "CRITICAL",
+	nagiosUnknown:  "UNKNOWN",
+}
+
+// nagiosStateRanks orders the states by precedence, the state with the highest rank is reported
+var nagiosStateRanks = map[int]int{
+	nagiosOK:       0,
+	nagiosUnknown:  1,
+	nagiosWarning:  2,
+	nagiosCritical: 3,
+}
+
+// nagiosPerf is a reading reported as performance data with its thresholds, the thresholds are
+// metrics of the same component
+type nagiosPerf struct {
+	prefix      string
+	reading     string
+	minWarning  string
+	maxWarning  string
+	minCritical string
+	maxCritical string
+}
+
+var nagiosPerfdata = []nagiosPerf{
+	{"temp", "chassis_temps_reading", "chassis_temps_min_warning", "chassis_temps_max_warning", "chassis_temps_min_failure", "chassis_temps_max_failure"},
+	{"fan", "chassis_fan_reading", "", "", "", ""},
+	{"volts", "chassis_volts_reading", "", "", "", ""},
+	{"power", "chassis_power_reading", "", "chassis_power_warn_level", "", "chassis_power_fail_level"},
+	{"current", "chassis_current_reading", "", "", "", ""},
+}
+
+var nagiosOpts struct {
+	include []string
+	exclude []string
+}
+
+// nagiosFilter matches the components which have a label with a value matching the regex,
+// optionally only the components of one collector
+type nagiosFilter struct {
+	collector string
+	label     string
+	value     *regexp.Regexp
+}
+
+// parseNagiosFilter parses a filter in the format "[collector:]label=regex"
+func parseNagiosFilter(s string) (nagiosFilter, error) {
+	f := nagiosFilter{}
+
+	selector, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return f, fmt.Errorf("invalid filter %q (must be [collector:]label=regex)", s)
+	}
+	if collector, label, ok := strings.Cut(selector, ":"); ok {
+		f.collector = collector
+		selector = label
+	}
+	if selector == "" {
+		return f, fmt.Errorf("invalid filter %q, the label is missing", s)
+	}
+	f.label = selector
+
+	re, err := regexp.Compile("^(?:" + value + ")$")
+	if err != nil {
+		return f, fmt.Errorf("invalid filter %q. %w", s, err)
+	}
+	f.value = re
+
+	return f, nil
+}
+
+// applies returns true if the filter applies to the collector of the component
+func (f nagiosFilter) applies(c *checkComponent) bool {
+	return f.collector == "" || f.collector == c.Collector
+}
+
+func (f nagiosFilter) matches(c *checkComponent) bool {
+	value, ok := c.Labels[f.label]
+	return f.applies(c) && ok && f.value.MatchString(value)
+}
+
+// filterComponents returns the components which match one of the includes (if an include applies
+// to the collector of the component) and none of the excludes
+func filterComponents(components []*checkComponent, includes []nagiosFilter, excludes []nagiosFilter) []*checkComponent {
+	filtered := []*checkComponent{}
+	for _, c := range components {
+		included := true
+		for _, include := range includes {
+			if include.applies(c) {
+				included = slices.ContainsFunc(includes, func(f nagiosFilter) bool { return f.matches(c) })
+				break
+			}
+		}
+		excluded := slices.ContainsFunc(excludes, func(f nagiosFilter) bool { return f.matches(c) })
+
+		if included && !excluded {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// runNagiosCommand runs the collectors once and prints the result as a Nagios plugin, returns the plugin state
+func runNagiosCommand(args []string) int {
+	nagiosFlags := newCommandFlags("nagios")
+	nagiosFlags.StringArrayVar(&nagiosOpts.include, "include", []string{}, "Only check the components matching the filter \"[collector:]label=regex\" (e.g., \"storage_pdisk:controller=0\"), can be repeated")
+	nagiosFlags.StringArrayVar(&nagiosOpts.exclude, "exclude", []string{}, "Don't check the components matching the filter \"[collector:]label=regex\" (e.g., \"processors:processor=CPU2\"), can be repeated")
+	if err := parseFlagsAndEnvVars(nagiosFlags, args); err != nil {
+		fmt.Printf("DELLHW UNKNOWN - failed to parse flags and env vars. %v\n", err)
+		return nagiosUnknown
+	}
+
+	includes, err := parseNagiosFilters(nagiosOpts.include)
+	if err != nil {
+		fmt.Printf("DELLHW UNKNOWN - %v\n", err)
+		return nagiosUnknown
+	}
+	excludes, err := parseNagiosFilters(nagiosOpts.exclude)
+	if err != nil {
+		fmt.Printf("DELLHW UNKNOWN - %v\n", err)
+		return nagiosUnknown
+	}
+
+	collectors, err := loadCommandCollectors()
+	if err != nil {
+		fmt.Printf("DELLHW UNKNOWN - couldn't load collectors. %v\n", err)
+		return nagiosUnknown
+	}
+
+	report := runCheck(collectors)
+	report.Components = filterComponents(report.Components, includes, excludes)
+
+	return writeNagios(os.Stdout, report)
+}
+
+func parseNagiosFilters(filters []string) ([]nagiosFilter, error) {
+	parsed := make([]nagiosFilter, 0, len(filters))
+	for _, filter := range filters {
+		f, err := parseNagiosFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, f)
+	}
+	return parsed, nil
+}
+
+// nagiosState maps the status (omreport.SeverityModelOrdered) to the plugin state
+func nagiosState(severity float64) int {
+	switch {
+	case severity >= criticalSeverity:
+		return nagiosCritical
+	case severity == 2:
+		return nagiosWarning
+	case severity == 1:
+		return nagiosUnknown
+	default:
+		return nagiosOK
+	}
+}
+
+// writeNagios writes the plugin output, the summary and performance data in the first line and
+// the components which aren't ok in the following lines, returns the plugin state
+func writeNagios(w io.Writer, report *checkReport) int {
+	state := nagiosOK
+	counts := map[int]int{}
+	checked := 0
+	problems := []string{}
+
+	for _, c := range report.Components {
+		if c.Status == "" {
+			continue
+		}
+		checked++
+
+		s := nagiosState(c.severity)
+		counts[s]++
+		if nagiosStateRanks[s] > nagiosStateRanks[state] {
+			state = s
+		}
+		if s != nagiosOK {
+			problem := fmt.Sprintf("%s %s: %s", c.Collector, c.labelsString(), c.Status)
+			if c.State != "" {
+				problem += " (" + c.State + ")"
+			}
+			problems = append(problems, problem)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(report.Errors)) {
+		if nagiosStateRanks[nagiosUnknown] > nagiosStateRanks[state] {
+			state = nagiosUnknown
+		}
+		problems = append(problems, fmt.Sprintf("%s: collector failed: %s", name, report.Errors[name]))
+	}
+
+	var summary string
+	if state == nagiosOK {
+		summary = fmt.Sprintf("%d components are ok", checked)
+	} else {
+		summary = fmt.Sprintf("%d critical, %d warning, %d unknown of %d components", counts[nagiosCritical], counts[nagiosWarning], counts[nagiosUnknown], checked)
+		if len(report.Errors) > 0 {
+			summary += fmt.Sprintf(", %d failed collectors", len(report.Errors))
+		}
+	}
+
+	line := fmt.Sprintf("DELLHW %s - %s", nagiosStateNames[state], summary)
+	if perfdata := nagiosPerfdataString(report.Components); perfdata != "" {
+		line += " | " + perfdata
+	}
+	fmt.Fprintln(w, line)
+	for _, problem := range problems {
+		fmt.Fprintln(w, problem)
+	}
+
+	return state
+}
+
+// nagiosPerfdataString returns the performance data of the readings of the components, e.g.,
+// "temp_CPU1_Temp=34;8:82;3:87"
+func nagiosPerfdataString(components []*checkComponent) string {
+	perfdata := []string{}
+	for _, c := range components {
+		for _, perf := range nagiosPerfdata {
+			reading, ok := c.Readings[perf.reading]
+			if !ok {
+				continue
+			}
+
+			label := perf.prefix
+			for _, name := range slices.Sorted(maps.Keys(c.Labels)) {
+				label += "_" + c.Labels[name]
+			}
+			if strings.ContainsAny(label, " '=") {
+				label = "'" + strings.ReplaceAll(label, "'", "''") + "'"
+			}
+
+			value := label + "=" + formatNagiosNumber(reading) + ";" +
+				nagiosRange(c, perf.minWarning, perf.maxWarning) + ";" +
+				nagiosRange(c, perf.minCritical, perf.maxCritical)
+			perfdata = append(perfdata, strings.TrimRight(value, ";"))
+		}
+	}
+	return strings.Join(perfdata, " ")
+}
+
+// nagiosRange returns the threshold range of the min and max readings of the component, a value
+// outside of the range is alerted (e.g., "8:82", "8:" for only a min and "~:82" for only a max)
+func nagiosRange(c *checkComponent, minReading string, maxReading string) string {
+	lower, hasLower := c.Readings[minReading]
+	upper, hasUpper := c.Readings[maxReading]
+
+	switch {
+	case hasLower && hasUpper:
+		return formatNagiosNumber(lower) + ":" + formatNagiosNumber(upper)
+	case hasLower:
+		return formatNagiosNumber(lower) + ":"
+	case hasUpper:
+		return "~:" + formatNagiosNumber(upper)
+	default:
+		return ""
+	}
+}
+
+func formatNagiosNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
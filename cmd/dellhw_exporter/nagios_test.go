@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNagiosBackend() *collector.FakeBackend {
+	backend := collector.NewFakeBackend()
+	backend.Values["Temps"] = []omreport.Value{
+		{Name: "chassis_temps", Value: "0", Labels: map[string]string{"component": "CPU1_Temp"}},
+		{Name: "chassis_temps_reading", Value: "34", Labels: map[string]string{"component": "CPU1_Temp"}},
+		{Name: "chassis_temps_min_warning", Value: "8", Labels: map[string]string{"component": "CPU1_Temp"}},
+		{Name: "chassis_temps_max_warning", Value: "82", Labels: map[string]string{"component": "CPU1_Temp"}},
+		{Name: "chassis_temps_min_failure", Value: "3", Labels: map[string]string{"component": "CPU1_Temp"}},
+		{Name: "chassis_temps_max_failure", Value: "87", Labels: map[string]string{"component": "CPU1_Temp"}},
+	}
+	backend.Values["PsAmpsSysboardPwr"] = []omreport.Value{
+		{Name: "chassis_power_reading", Value: "84"},
+		{Name: "chassis_power_warn_level", Value: "896"},
+		{Name: "chassis_power_fail_level", Value: "980"},
+	}
+	backend.Values["StorageController"] = []omreport.Value{
+		{Name: "storage_controller_status", Value: "0", Labels: map[string]string{"id": "0"}},
+		{Name: "storage_controller_status", Value: "0", Labels: map[string]string{"id": "1"}},
+	}
+	backend.Values["StoragePdisk"] = []omreport.Value{
+		{Name: "storage_pdisk_status", Value: "0", Labels: map[string]string{"controller": "0", "disk": "0_1_0"}},
+		{Name: "storage_pdisk_status", Value: "3", Labels: map[string]string{"controller": "1", "disk": "0_1_0"}},
+		{Name: "storage_pdisk_state", Value: "4", Labels: map[string]string{"controller": "1", "disk": "0_1_0"}},
+	}
+	return backend
+}
+
+func TestWriteNagios(t *testing.T) {
+	backend := newNagiosBackend()
+	report := runCheck(newCheckCollectors(t, backend, "temps", "ps_amps_sysboard_pwr", "storage_pdisk"))
+
+	out := &bytes.Buffer{}
+	assert.Equal(t, nagiosCritical, writeNagios(out, report))
+	assert.Equal(t, `DELLHW CRITICAL - 1 critical, 0 warning, 0 unknown of 3 components | power=84;~:896;~:980 temp_CPU1_Temp=34;8:82;3:87
+storage_pdisk controller=1 disk=0_1_0: Critical (Failed)
+`, out.String())
+
+	// The failed disk is excluded
+	excludes, err := parseNagiosFilters([]string{"storage_pdisk:controller=1"})
+	require.NoError(t, err)
+	report.Components = filterComponents(report.Components, nil, excludes)
+	out.Reset()
+	assert.Equal(t, nagiosOK, writeNagios(out, report))
+	assert.Equal(t, "DELLHW OK - 2 components are ok | power=84;~:896;~:980 temp_CPU1_Temp=34;8:82;3:87\n", out.String())
+}
+
+func TestWriteNagiosFixtures(t *testing.T) {
+	// The fixture host is healthy, its unoccupied CPU socket isn't unknown
+	omr := &omreport.OMReport{
+		Options: newCommandOptions(),
+		Reader:  omreport.NewFixtureReader("../../collector/testdata/omreport"),
+	}
+	collectors, err := loadCollectors(omr, defaultCollectors, nil)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	report := runCheck(collectors)
+	assert.Equal(t, nagiosOK, writeNagios(out, report), out.String())
+	assert.Regexp(t, `^DELLHW OK - \d+ components are ok \| `, out.String())
+	assert.NotContains(t, out.String(), "CPU2")
+	assert.Equal(t, checkExitOK, report.exitCode())
+}
+
+func TestWriteNagiosStates(t *testing.T) {
+	backend := collector.NewFakeBackend()
+	backend.Values["Chassis"] = []omreport.Value{
+		{Name: "chassis_status", Value: "1", Labels: map[string]string{"component": "Fans"}},
+		{Name: "chassis_status", Value: "2", Labels: map[string]string{"component": "Power Supplies"}},
+	}
+	backend.Errors["Memory"] = errors.New("omreport failed")
+
+	// Warning takes precedence over unknown
+	out := &bytes.Buffer{}
+	report := runCheck(newCheckCollectors(t, backend, "chassis", "memory"))
+	assert.Equal(t, nagiosWarning, writeNagios(out, report))
+	assert.Equal(t, `DELLHW WARNING - 0 critical, 1 warning, 1 unknown of 2 components, 1 failed collectors
+chassis component=Fans: Unknown
+chassis component=Power Supplies: Non-Critical
+memory: collector failed: omreport failed
+`, out.String())
+
+	// A failed collector is unknown
+	backend.Values["Chassis"] = nil
+	out.Reset()
+	report = runCheck(newCheckCollectors(t, backend, "chassis", "memory"))
+	assert.Equal(t, nagiosUnknown, writeNagios(out, report))
+}
+
+func TestNagiosFilters(t *testing.T) {
+	backend := newNagiosBackend()
+	report := runCheck(newCheckCollectors(t, backend, "storage_controller", "storage_pdisk"))
+	require.Len(t, report.Components, 4)
+
+	labels := func(components []*checkComponent) []string {
+		l := []string{}
+		for _, c := range components {
+			l = append(l, c.Collector+" "+c.labelsString())
+		}
+		return l
+	}
+
+	// Includes only apply to the components of their collector
+	includes, err := parseNagiosFilters([]string{"storage_pdisk:controller=0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"storage_controller id=0",
+		"storage_controller id=1",
+		"storage_pdisk controller=0 disk=0_1_0",
+	}, labels(filterComponents(report.Components, includes, nil)))
+
+	// Filters without a collector apply to all collectors, the regex must match the whole value
+	includes, err = parseNagiosFilters([]string{"controller=1", "id=0|1"})
+	require.NoError(t, err)
+	excludes, err := parseNagiosFilters([]string{"id=1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"storage_controller id=0",
+		"storage_pdisk controller=1 disk=0_1_0",
+	}, labels(filterComponents(report.Components, includes, excludes)))
+
+	for _, invalid := range []string{"controller", "storage_pdisk:=0", "controller=("} {
+		_, err := parseNagiosFilter(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,361 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/version"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// OTLP protocols, named like the values of OTEL_EXPORTER_OTLP_PROTOCOL
+const (
+	otlpProtocolHTTP = "http/protobuf"
+	otlpProtocolGRPC = "grpc"
+)
+
+// otlpGRPCMethod is the path of the OTLP metrics export gRPC method
+const otlpGRPCMethod = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+
+// otlpScopeName is the name of the instrumentation scope of the exported metrics
+const otlpScopeName = "github.com/galexrt/dellhw_exporter"
+
+// otlpRetryableGRPCCodes are the gRPC status codes which are retried, see the OTLP specification
+var otlpRetryableGRPCCodes = []string{
+	"1",  // CANCELLED
+	"4",  // DEADLINE_EXCEEDED
+	"8",  // RESOURCE_EXHAUSTED
+	"10", // ABORTED
+	"11", // OUT_OF_RANGE
+	"14", // UNAVAILABLE
+	"15", // DATA_LOSS
+}
+
+// otlpTarget exports the metrics as OTLP ExportMetricsServiceRequests over HTTP or gRPC
+type otlpTarget struct {
+	url     string
+	grpc    bool
+	headers map[string]string
+	// resource are the attributes of the resource (the host) of the metrics
+	resource map[string]string
+	// startTime is the start time of the cumulative metrics (counters, histograms and summaries)
+	startTime time.Time
+	client    *http.Client
+}
+
+// newOTLPTarget returns the OTLP target of the endpoint, the client is used for OTLP/HTTP and
+// gRPC with TLS, plaintext gRPC requires a client speaking HTTP/2 without TLS
+func newOTLPTarget(endpoint string, protocol string, client *http.Client, resource map[string]string) (*otlpTarget, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid otlp endpoint %q (must be an http:// or https:// URL)", endpoint)
+	}
+
+	t := &otlpTarget{
+		url:       endpoint,
+		headers:   opts.pushOTLPHeaders,
+		resource:  resource,
+		startTime: time.Now(),
+		client:    client,
+	}
+
+	switch protocol {
+	case otlpProtocolHTTP:
+	case otlpProtocolGRPC:
+		t.grpc = true
+		t.url = strings.TrimSuffix(endpoint, "/") + otlpGRPCMethod
+		if u.Scheme == "http" {
+			protocols := &http.Protocols{}
+			protocols.SetUnencryptedHTTP2(true)
+			t.client = &http.Client{Transport: &http.Transport{Protocols: protocols}}
+		}
+	default:
+		return nil, fmt.Errorf("invalid otlp protocol %q (must be %s or %s)", protocol, otlpProtocolHTTP, otlpProtocolGRPC)
+	}
+
+	return t, nil
+}
+
+// otlpResource returns the resource attributes, the host and the chassis (model and service tag)
+// the metrics are from and the extra attributes
+func otlpResource(omr *omreport.OMReport, instance string, extra map[string]string) map[string]string {
+	resource := map[string]string{
+		"service.name":        opts.pushJob,
+		"service.instance.id": instance,
+		"service.version":     version.Version,
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		resource["host.name"] = hostname
+	}
+
+	info, err := omr.ChassisInfo()
+	if err != nil {
+		logger.Warn("failed to get chassis info for the otlp resource attributes", "error", err.Error())
+	}
+	for _, value := range info {
+		if model, ok := value.Labels["chassis_model"]; ok {
+			resource["dell.chassis.model"] = model
+		}
+	}
+	if serviceTag, err := omr.ChassisServiceTag(); err != nil {
+		logger.Warn("failed to get chassis service tag for the otlp resource attributes", "error", err.Error())
+	} else {
+		resource["dell.chassis.service_tag"] = serviceTag
+	}
+
+	maps.Copy(resource, extra)
+	return resource
+}
+
+func (t *otlpTarget) send(ctx context.Context, batch *pushBatch) error {
+	body := encodeOTLPRequest(batch, t.resource, t.startTime)
+
+	contentType := "application/x-protobuf"
+	if t.grpc {
+		// A gRPC message is prefixed with the compressed flag and its length
+		framed := make([]byte, 5, 5+len(body))
+		binary.BigEndian.PutUint32(framed[1:], uint32(len(body)))
+		body = append(framed, body...)
+		contentType = "application/grpc"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return &pushPermanentError{err}
+	}
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "dellhw_exporter/"+version.Version)
+	if t.grpc {
+		req.Header.Set("TE", "trailers")
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// The body must be read completely for the gRPC status in the trailers
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	io.Copy(io.Discard, resp.Body)
+
+	if t.grpc {
+		return otlpGRPCError(resp)
+	}
+
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	err = fmt.Errorf("unexpected status code %d while exporting to %s: %s", resp.StatusCode, t.url, bytes.TrimSpace(msg))
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return err
+	default:
+		return &pushPermanentError{err}
+	}
+}
+
+// otlpGRPCError returns the error of the gRPC status of the response, nil if it is OK
+func otlpGRPCError(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d of the grpc response", resp.StatusCode)
+	}
+
+	// A response without a message has the status in the headers ("trailers-only")
+	status := resp.Trailer.Get("Grpc-Status")
+	message := resp.Trailer.Get("Grpc-Message")
+	if status == "" {
+		status = resp.Header.Get("Grpc-Status")
+		message = resp.Header.Get("Grpc-Message")
+	}
+	if status == "0" {
+		return nil
+	}
+	if status == "" {
+		return fmt.Errorf("grpc response without a status")
+	}
+
+	if unescaped, err := url.PathUnescape(message); err == nil {
+		message = unescaped
+	}
+	err := fmt.Errorf("grpc status %s while exporting: %s", status, message)
+	if !slices.Contains(otlpRetryableGRPCCodes, status) {
+		return &pushPermanentError{err}
+	}
+	return err
+}
+
+// buffered the OTLP data points have their timestamps
+func (t *otlpTarget) buffered() bool {
+	return true
+}
+
+// encodeOTLPRequest encodes the batch as an OTLP ExportMetricsServiceRequest protobuf message.
+// Counters are monotonic cumulative sums, gauges and untyped metrics are gauges and the labels are
+// the attributes of the data points
+func encodeOTLPRequest(batch *pushBatch, resource map[string]string, startTime time.Time) []byte {
+	timestamp := uint64(batch.timestamp.UnixNano())
+	start := uint64(startTime.UnixNano())
+
+	var metrics []byte
+	for _, mf := range batch.families {
+		if len(mf.GetMetric()) == 0 {
+			continue
+		}
+
+		var points []byte
+		var dataField protowire.Number
+		for _, m := range mf.GetMetric() {
+			var point []byte
+			switch mf.GetType() {
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				var bounds, counts []byte
+				var cumulative uint64
+				for _, b := range h.GetBucket() {
+					if math.IsInf(b.GetUpperBound(), 1) {
+						continue
+					}
+					bounds = protowire.AppendFixed64(bounds, math.Float64bits(b.GetUpperBound()))
+					counts = protowire.AppendFixed64(counts, b.GetCumulativeCount()-cumulative)
+					cumulative = b.GetCumulativeCount()
+				}
+				counts = protowire.AppendFixed64(counts, h.GetSampleCount()-cumulative)
+
+				point = otlpAppendAttributes(point, 9, m.GetLabel())
+				point = otlpAppendFixed64(point, 2, start)
+				point = otlpAppendFixed64(point, 3, timestamp)
+				point = otlpAppendFixed64(point, 4, h.GetSampleCount())
+				point = otlpAppendFixed64(point, 5, math.Float64bits(h.GetSampleSum()))
+				point = otlpAppendMessage(point, 6, counts)
+				point = otlpAppendMessage(point, 7, bounds)
+				dataField = 9
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				point = otlpAppendAttributes(point, 7, m.GetLabel())
+				point = otlpAppendFixed64(point, 2, start)
+				point = otlpAppendFixed64(point, 3, timestamp)
+				point = otlpAppendFixed64(point, 4, s.GetSampleCount())
+				point = otlpAppendFixed64(point, 5, math.Float64bits(s.GetSampleSum()))
+				for _, q := range s.GetQuantile() {
+					var quantile []byte
+					quantile = otlpAppendFixed64(quantile, 1, math.Float64bits(q.GetQuantile()))
+					quantile = otlpAppendFixed64(quantile, 2, math.Float64bits(q.GetValue()))
+					point = otlpAppendMessage(point, 6, quantile)
+				}
+				dataField = 11
+			case dto.MetricType_COUNTER:
+				point = otlpAppendAttributes(point, 7, m.GetLabel())
+				point = otlpAppendFixed64(point, 2, start)
+				point = otlpAppendFixed64(point, 3, timestamp)
+				point = otlpAppendFixed64(point, 4, math.Float64bits(m.GetCounter().GetValue()))
+				dataField = 7
+			default:
+				value := m.GetGauge().GetValue()
+				if mf.GetType() == dto.MetricType_UNTYPED {
+					value = m.GetUntyped().GetValue()
+				}
+				point = otlpAppendAttributes(point, 7, m.GetLabel())
+				point = otlpAppendFixed64(point, 3, timestamp)
+				point = otlpAppendFixed64(point, 4, math.Float64bits(value))
+				dataField = 5
+			}
+			points = otlpAppendMessage(points, 1, point)
+		}
+
+		switch dataField {
+		case 7:
+			// Cumulative (2) and monotonic
+			points = protowire.AppendTag(points, 2, protowire.VarintType)
+			points = protowire.AppendVarint(points, 2)
+			points = protowire.AppendTag(points, 3, protowire.VarintType)
+			points = protowire.AppendVarint(points, 1)
+		case 9:
+			points = protowire.AppendTag(points, 2, protowire.VarintType)
+			points = protowire.AppendVarint(points, 2)
+		}
+
+		var metric []byte
+		metric = otlpAppendString(metric, 1, mf.GetName())
+		metric = otlpAppendString(metric, 2, mf.GetHelp())
+		metric = otlpAppendMessage(metric, dataField, points)
+		metrics = otlpAppendMessage(metrics, 2, metric)
+	}
+
+	var scope []byte
+	scope = otlpAppendString(scope, 1, otlpScopeName)
+	scope = otlpAppendString(scope, 2, version.Version)
+	scopeMetrics := otlpAppendMessage(nil, 1, scope)
+	scopeMetrics = append(scopeMetrics, metrics...)
+
+	var res []byte
+	for _, key := range slices.Sorted(maps.Keys(resource)) {
+		res = otlpAppendMessage(res, 1, otlpKeyValue(key, resource[key]))
+	}
+
+	var resourceMetrics []byte
+	resourceMetrics = otlpAppendMessage(resourceMetrics, 1, res)
+	resourceMetrics = otlpAppendMessage(resourceMetrics, 2, scopeMetrics)
+
+	return otlpAppendMessage(nil, 1, resourceMetrics)
+}
+
+// otlpKeyValue encodes a KeyValue message with a string value
+func otlpKeyValue(key string, value string) []byte {
+	anyValue := otlpAppendString(nil, 1, value)
+	kv := otlpAppendString(nil, 1, key)
+	return otlpAppendMessage(kv, 2, anyValue)
+}
+
+func otlpAppendAttributes(b []byte, num protowire.Number, labels []*dto.LabelPair) []byte {
+	for _, l := range labels {
+		b = otlpAppendMessage(b, num, otlpKeyValue(l.GetName(), l.GetValue()))
+	}
+	return b
+}
+
+func otlpAppendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func otlpAppendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func otlpAppendFixed64(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, v)
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// protoMessage are the decoded fields of a protobuf message, the values are the raw bytes of
+// length-delimited fields and the numbers of fixed64 and varint fields
+type protoMessage map[protowire.Number][]any
+
+func decodeProto(t *testing.T, b []byte) protoMessage {
+	t.Helper()
+
+	msg := protoMessage{}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+
+		var value any
+		switch typ {
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(b)
+		case protowire.Fixed64Type:
+			value, n = protowire.ConsumeFixed64(b)
+		case protowire.VarintType:
+			value, n = protowire.ConsumeVarint(b)
+		default:
+			t.Fatalf("unexpected wire type %d of field %d", typ, num)
+		}
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		msg[num] = append(msg[num], value)
+	}
+	return msg
+}
+
+// message returns the i-th message of the field
+func (m protoMessage) message(t *testing.T, num protowire.Number, i int) protoMessage {
+	t.Helper()
+	require.Greater(t, len(m[num]), i, "field %d", num)
+	return decodeProto(t, m[num][i].([]byte))
+}
+
+func (m protoMessage) string(num protowire.Number) string {
+	if len(m[num]) == 0 {
+		return ""
+	}
+	return string(m[num][0].([]byte))
+}
+
+func (m protoMessage) double(num protowire.Number) float64 {
+	return math.Float64frombits(m[num][0].(uint64))
+}
+
+// keyValues returns the KeyValue messages (with string values) of the field
+func (m protoMessage) keyValues(t *testing.T, num protowire.Number) map[string]string {
+	kvs := map[string]string{}
+	for i := range m[num] {
+		kv := m.message(t, num, i)
+		kvs[kv.string(1)] = kv.message(t, 2, 0).string(1)
+	}
+	return kvs
+}
+
+// otlpMetrics returns the metrics of the ExportMetricsServiceRequest by name and the resource attributes
+func otlpMetrics(t *testing.T, b []byte) (map[string]protoMessage, map[string]string) {
+	t.Helper()
+
+	resourceMetrics := decodeProto(t, b).message(t, 1, 0)
+	resource := resourceMetrics.message(t, 1, 0).keyValues(t, 1)
+	scopeMetrics := resourceMetrics.message(t, 2, 0)
+	assert.Equal(t, otlpScopeName, scopeMetrics.message(t, 1, 0).string(1))
+
+	metrics := map[string]protoMessage{}
+	for i := range scopeMetrics[2] {
+		metric := scopeMetrics.message(t, 2, i)
+		metrics[metric.string(1)] = metric
+	}
+	return metrics, resource
+}
+
+func TestEncodeOTLPRequest(t *testing.T) {
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "test_seconds",
+		Help:    "Test histogram.",
+		Buckets: []float64{0.5, 1},
+	})
+	histogram.Observe(0.7)
+	histogram.Observe(2)
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_total", Help: "Test counter."})
+	counter.Add(3)
+	reg := newTestPushRegistry()
+	reg.MustRegister(histogram, counter)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	start := time.Unix(1700000000, 0)
+	batch := &pushBatch{timestamp: start.Add(time.Minute), families: families}
+
+	metrics, resource := otlpMetrics(t, encodeOTLPRequest(batch, map[string]string{"host.name": "host1"}, start))
+	assert.Equal(t, map[string]string{"host.name": "host1"}, resource)
+
+	// Gauge with the labels as attributes
+	gauge := metrics["dell_hw_test_value"].message(t, 5, 0)
+	point := gauge.message(t, 1, 0)
+	assert.Equal(t, map[string]string{"collector": "ok"}, point.keyValues(t, 7))
+	assert.Equal(t, uint64(batch.timestamp.UnixNano()), point[3][0])
+	assert.Equal(t, 1.0, point.double(4))
+	assert.Empty(t, point[2], "gauges have no start time")
+
+	// Counter as a cumulative monotonic sum
+	sum := metrics["test_total"].message(t, 7, 0)
+	assert.Equal(t, "Test counter.", metrics["test_total"].string(2))
+	assert.Equal(t, []any{uint64(2)}, sum[2])
+	assert.Equal(t, []any{uint64(1)}, sum[3])
+	point = sum.message(t, 1, 0)
+	assert.Equal(t, uint64(start.UnixNano()), point[2][0])
+	assert.Equal(t, 3.0, point.double(4))
+
+	// Histogram with the bucket counts of each bucket
+	h := metrics["test_seconds"].message(t, 9, 0)
+	assert.Equal(t, []any{uint64(2)}, h[2])
+	point = h.message(t, 1, 0)
+	assert.Equal(t, uint64(2), point[4][0])
+	assert.InDelta(t, 2.7, point.double(5), 0.0001)
+	counts := point[6][0].([]byte)
+	bounds := point[7][0].([]byte)
+	require.Len(t, counts, 3*8)
+	require.Len(t, bounds, 2*8)
+	assert.Equal(t, []uint64{0, 1, 1}, []uint64{binary.LittleEndian.Uint64(counts), binary.LittleEndian.Uint64(counts[8:]), binary.LittleEndian.Uint64(counts[16:])})
+	assert.Equal(t, 0.5, math.Float64frombits(binary.LittleEndian.Uint64(bounds)))
+	assert.Equal(t, 1.0, math.Float64frombits(binary.LittleEndian.Uint64(bounds[8:])))
+}
+
+func TestOTLPResource(t *testing.T) {
+	opts.pushJob = "dellhw_exporter"
+	omr := &omreport.OMReport{
+		Options: &omreport.Options{},
+		Reader:  omreport.NewFixtureReader("../../collector/testdata/omreport"),
+	}
+
+	resource := otlpResource(omr, "host1", map[string]string{"datacenter": "dc1"})
+	assert.Equal(t, "dellhw_exporter", resource["service.name"])
+	assert.Equal(t, "host1", resource["service.instance.id"])
+	assert.Equal(t, "PowerEdge_Rxxxx", resource["dell.chassis.model"])
+	assert.Equal(t, "123XXX", resource["dell.chassis.service_tag"])
+	assert.Equal(t, "dc1", resource["datacenter"])
+	assert.NotEmpty(t, resource["host.name"])
+}
+
+func TestOTLPHTTP(t *testing.T) {
+	failures := 1
+	var requests [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/metrics", req.URL.Path)
+		assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+		assert.Equal(t, "tenant1", req.Header.Get("X-Scope-OrgID"))
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		requests = append(requests, body)
+	}))
+	defer server.Close()
+
+	opts.pushOTLPHeaders = map[string]string{"X-Scope-OrgID": "tenant1"}
+	defer func() { opts.pushOTLPHeaders = nil }()
+	target, err := newOTLPTarget(server.URL+"/v1/metrics", otlpProtocolHTTP, server.Client(), map[string]string{"host.name": "host1"})
+	require.NoError(t, err)
+
+	q := newTestPushQueue("otlp", target)
+	require.NoError(t, pushMetrics(context.Background(), newTestPushRegistry(), []*pushQueue{q}))
+	require.Len(t, requests, 1)
+	metrics, resource := otlpMetrics(t, requests[0])
+	assert.Contains(t, metrics, "dell_hw_test_value")
+	assert.Equal(t, "host1", resource["host.name"])
+
+	// Rejected requests aren't retried
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	err = target.send(context.Background(), &pushBatch{timestamp: time.Now()})
+	assert.ErrorAs(t, err, new(*pushPermanentError))
+
+	_, err = newOTLPTarget("otel-collector:4317", otlpProtocolGRPC, server.Client(), nil)
+	assert.ErrorContains(t, err, "invalid otlp endpoint")
+	_, err = newOTLPTarget(server.URL, "http/json", server.Client(), nil)
+	assert.ErrorContains(t, err, "invalid otlp protocol")
+}
+
+func TestOTLPGRPC(t *testing.T) {
+	status := "14"
+	var messages [][]byte
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, 2, req.ProtoMajor)
+		assert.Equal(t, otlpGRPCMethod, req.URL.Path)
+		assert.Equal(t, "application/grpc", req.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(body), 5)
+		assert.Equal(t, byte(0), body[0])
+		assert.Equal(t, uint32(len(body)-5), binary.BigEndian.Uint32(body[1:5]))
+
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+		w.WriteHeader(http.StatusOK)
+		if status == "0" {
+			messages = append(messages, body[5:])
+			// An empty ExportMetricsServiceResponse
+			w.Write([]byte{0, 0, 0, 0, 0})
+		}
+		w.Header().Set("Grpc-Status", status)
+		w.Header().Set("Grpc-Message", "collector%20unavailable")
+		if status == "14" {
+			status = "0"
+		}
+	}))
+	server.Config.Protocols = &http.Protocols{}
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	defer server.Close()
+
+	target, err := newOTLPTarget(server.URL, otlpProtocolGRPC, nil, map[string]string{"host.name": "host1"})
+	require.NoError(t, err)
+
+	// The first request is unavailable and retried
+	q := newTestPushQueue("otlp", target)
+	require.NoError(t, pushMetrics(context.Background(), newTestPushRegistry(), []*pushQueue{q}))
+	require.Len(t, messages, 1)
+	metrics, _ := otlpMetrics(t, messages[0])
+	assert.Contains(t, metrics, "dell_hw_test_value")
+
+	// INVALID_ARGUMENT isn't retried
+	status = "3"
+	err = target.send(context.Background(), &pushBatch{timestamp: time.Now()})
+	assert.ErrorAs(t, err, new(*pushPermanentError))
+	assert.ErrorContains(t, err, "collector unavailable")
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omsa"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	healthyPath = "/-/healthy"
+	readyPath   = "/-/ready"
+
+	// readyCheckInterval is the interval of the OMSA check of the readiness endpoint, omreport isn't
+	// run if a collector succeeded within the interval
+	readyCheckInterval = 30 * time.Second
+)
+
+// handleHealthy returns 200 as long as the process is up
+func (p *program) handleHealthy(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "Healthy")
+}
+
+// handleReady returns 200 if the exporter is ready and 503 with the reasons otherwise
+func (p *program) handleReady(w http.ResponseWriter, r *http.Request) {
+	problems := p.readyProblems()
+	if len(problems) > 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, "Not ready")
+		for _, problem := range problems {
+			fmt.Fprintln(w, problem)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "Ready")
+}
+
+// readyProblems returns why the exporter isn't ready: the omreport executable isn't found, the OMSA
+// services are down (if the preflight check is enabled) or don't respond or no collector succeeded yet.
+// The OMSA services are checked in the background (see runReadyChecks), only the last result is used.
+func (p *program) readyProblems() []string {
+	if _, err := exec.LookPath(p.omr.Options.OMReportExecutable); err != nil {
+		return []string{fmt.Sprintf("omreport executable not found: %v", err)}
+	}
+
+	problems := []string{}
+	p.readyCheckMutex.Lock()
+	if p.readyCheckTime.IsZero() {
+		problems = append(problems, "OMSA services not checked yet")
+	} else if p.readyCheckErr != nil {
+		problems = append(problems, p.readyCheckErr.Error())
+	}
+	p.readyCheckMutex.Unlock()
+	if p.collector.lastSuccess().IsZero() {
+		problems = append(problems, "no collector succeeded yet")
+	}
+	return problems
+}
+
+// runReadyChecks checks the OMSA services every readyCheckInterval for the readiness endpoint, which
+// responds immediately with the last result. The collectors are run once after the first check if
+// nothing was collected yet, so the exporter becomes ready without a scrape (which might only happen
+// once it is ready).
+func (p *program) runReadyChecks() {
+	p.updateReadyCheck()
+	if p.collector.lastCollect().IsZero() {
+		ch := make(chan prometheus.Metric)
+		go func() {
+			p.collector.Collect(ch)
+			close(ch)
+		}()
+		for range ch {
+		}
+	}
+
+	ticker := time.NewTicker(readyCheckInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		p.updateReadyCheck()
+	}
+}
+
+// updateReadyCheck checks the OMSA services, omreport isn't run if a collector succeeded recently
+func (p *program) updateReadyCheck() {
+	err := p.checkOMSA()
+
+	p.readyCheckMutex.Lock()
+	defer p.readyCheckMutex.Unlock()
+	p.readyCheckTime, p.readyCheckErr = time.Now(), err
+}
+
+// checkOMSA returns an error if the OMSA services are down (with the preflight check) or don't respond
+// to a (cheap) omreport command
+func (p *program) checkOMSA() error {
+	if p.collector.preflight != nil {
+		// omreport would only fail while the services are down
+		if err := omsa.Err(p.collector.preflight.Check()); err != nil {
+			return err
+		}
+	}
+	if time.Since(p.collector.lastSuccess()) < readyCheckInterval {
+		return nil
+	}
+	if _, err := p.omr.ChassisInfo(); err != nil {
+		return fmt.Errorf("OMSA services aren't responding: %w", err)
+	}
+	return nil
+}
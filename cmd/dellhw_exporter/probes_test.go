@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/galexrt/dellhw_exporter/pkg/omsa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getProbe(t *testing.T, handler http.HandlerFunc) (int, string) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec.Code, rec.Body.String()
+}
+
+func TestHealthy(t *testing.T) {
+	code, body := getProbe(t, (&program{}).handleHealthy)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Healthy\n", body)
+}
+
+func TestReady(t *testing.T) {
+	executable := filepath.Join(t.TempDir(), "omreport")
+	require.NoError(t, os.WriteFile(executable, []byte("#!/bin/sh\n"), 0o755))
+
+	// The OMSA services don't respond for the first check
+	omsaErr := errors.New("omreport failed")
+	checks := 0
+	fixtures := omreport.NewFixtureReader("../../collector/testdata/omreport")
+	tc := &testCollector{name: "ok"}
+	p := &program{
+		collector: NewDellHWCollector(map[string]collector.Collector{"ok": tc}, false, 0),
+		omr: &omreport.OMReport{
+			Options: &omreport.Options{OMReportExecutable: filepath.Join(t.TempDir(), "missing")},
+			Reader: func(f func(omreport.Output), mode omreport.ReaderMode, cmd string, args ...string) error {
+				checks++
+				if omsaErr != nil {
+					return omsaErr
+				}
+				return fixtures(f, mode, cmd, args...)
+			},
+		},
+	}
+
+	code, body := getProbe(t, p.handleReady)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, body, "omreport executable not found")
+	assert.Equal(t, 0, checks)
+
+	p.omr.Options.OMReportExecutable = executable
+	_, body = getProbe(t, p.handleReady)
+	assert.Equal(t, "Not ready\nOMSA services not checked yet\nno collector succeeded yet\n", body)
+
+	// The endpoint only returns the result of the last check, it doesn't run omreport
+	p.updateReadyCheck()
+	for range 2 {
+		code, body = getProbe(t, p.handleReady)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "Not ready\nOMSA services aren't responding: omreport failed\nno collector succeeded yet\n", body)
+	}
+	assert.Equal(t, 1, checks)
+
+	omsaErr = nil
+	p.updateReadyCheck()
+	_, body = getProbe(t, p.handleReady)
+	assert.Equal(t, "Not ready\nno collector succeeded yet\n", body)
+	assert.Equal(t, 2, checks)
+
+	// The collectors are never run by the endpoint
+	assert.Equal(t, int32(0), tc.updates.Load())
+
+	// omreport isn't run if a collector succeeded recently
+	collectAndCount(t, p.collector)
+	p.updateReadyCheck()
+	code, body = getProbe(t, p.handleReady)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Ready\n", body)
+	assert.Equal(t, 2, checks)
+}
+
+func TestRunReadyChecks(t *testing.T) {
+	executable := filepath.Join(t.TempDir(), "omreport")
+	require.NoError(t, os.WriteFile(executable, []byte("#!/bin/sh\n"), 0o755))
+
+	tc := &testCollector{name: "ok"}
+	p := &program{
+		collector: NewDellHWCollector(map[string]collector.Collector{"ok": tc}, false, 0),
+		omr: &omreport.OMReport{
+			Options: &omreport.Options{OMReportExecutable: executable},
+			Reader:  omreport.NewFixtureReader("../../collector/testdata/omreport"),
+		},
+	}
+
+	// The exporter becomes ready without a scrape, the collectors are run once at the start
+	go p.runReadyChecks()
+	require.Eventually(t, func() bool {
+		code, _ := getProbe(t, p.handleReady)
+		return code == http.StatusOK
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), tc.updates.Load())
+}
+
+func TestReadyOMSAServicesDown(t *testing.T) {
+	executable := filepath.Join(t.TempDir(), "omreport")
+	require.NoError(t, os.WriteFile(executable, []byte("#!/bin/sh\n"), 0o755))
+
+	checks := 0
+	p := &program{
+		collector: NewDellHWCollector(map[string]collector.Collector{"ok": &testCollector{name: "ok"}}, false, 0),
+		omr: &omreport.OMReport{
+			Options: &omreport.Options{OMReportExecutable: executable},
+			Reader: func(f func(omreport.Output), mode omreport.ReaderMode, cmd string, args ...string) error {
+				checks++
+				return nil
+			},
+		},
+	}
+	p.collector.preflight = omsa.New(omsa.Options{Services: []string{"dsm_sa_datamgrd"}, ProcDir: t.TempDir()})
+
+	// No omreport command is run while the services are down
+	p.updateReadyCheck()
+	code, body := getProbe(t, p.handleReady)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "Not ready\nOMSA services are down: dsm_sa_datamgrd not running\nno collector succeeded yet\n", body)
+	assert.Equal(t, 0, checks)
+
+	// The status page shows the result of the check of the last collection
+	rec := httptest.NewRecorder()
+	p.handleStatusPage(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.NotContains(t, rec.Body.String(), "Service dsm_sa_datamgrd")
+	collectAndCount(t, p.collector)
+	rec = httptest.NewRecorder()
+	p.handleStatusPage(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Contains(t, rec.Body.String(), `<tr><th>Service dsm_sa_datamgrd</th><td class="critical">Down: not running</td></tr>`)
+}
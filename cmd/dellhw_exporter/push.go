@@ -0,0 +1,497 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/config"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// pushMaxBackoff caps the backoff between the retries of a push
+const pushMaxBackoff = 30 * time.Second
+
+var (
+	pushErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: collector.Namespace,
+			Subsystem: "push",
+			Name:      "errors_total",
+			Help:      "dellhw_exporter: Number of pushes which failed after all retries, by target.",
+		},
+		[]string{"target"},
+	)
+
+	pushBufferedBatches = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: collector.Namespace,
+			Subsystem: "push",
+			Name:      "buffered_batches",
+			Help:      "dellhw_exporter: Number of gathered batches of metrics waiting to be pushed, by target.",
+		},
+		[]string{"target"},
+	)
+)
+
+// pushBatch are the metrics gathered at one time
+type pushBatch struct {
+	timestamp time.Time
+	families  []*dto.MetricFamily
+}
+
+// pushTarget is a system the metrics are pushed to
+type pushTarget interface {
+	// send pushes the batch, errors wrapped in a pushPermanentError aren't retried
+	send(ctx context.Context, batch *pushBatch) error
+	// buffered returns true if the target stores the samples with their timestamps, only then the
+	// batches which couldn't be pushed are buffered, otherwise only the latest batch is pushed
+	buffered() bool
+}
+
+// pushPermanentError is an error of a push which fails again when retried (e.g., a bad request)
+type pushPermanentError struct {
+	err error
+}
+
+func (e *pushPermanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *pushPermanentError) Unwrap() error {
+	return e.err
+}
+
+// pushQueue buffers the batches of a target and pushes them in order with retries
+type pushQueue struct {
+	name       string
+	target     pushTarget
+	timeout    time.Duration
+	retries    int
+	backoff    time.Duration
+	bufferSize int
+
+	// mutex protects the batches, which are added while a flush is pushing them
+	mutex   sync.Mutex
+	batches []*pushBatch
+	// pending is signaled when a batch is added, for run
+	pending chan struct{}
+}
+
+// enqueue adds the batch to the queue, the oldest batches are dropped if the buffer is full
+func (q *pushQueue) enqueue(batch *pushBatch) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if !q.target.buffered() {
+		q.batches = q.batches[:0]
+	}
+	q.batches = append(q.batches, batch)
+
+	if dropped := len(q.batches) - max(q.bufferSize, 1); dropped > 0 {
+		logger.Warn("push buffer is full, dropping the oldest batches", "target", q.name, "dropped", dropped)
+		q.batches = slices.Delete(q.batches, 0, dropped)
+	}
+	pushBufferedBatches.WithLabelValues(q.name).Set(float64(len(q.batches)))
+}
+
+// flush pushes the queued batches, oldest first. A batch which couldn't be pushed stays queued (with
+// the newer batches) for the next flush, unless the error is permanent
+func (q *pushQueue) flush(ctx context.Context) error {
+	var errs []error
+	for {
+		batch := q.head()
+		if batch == nil {
+			return errors.Join(errs...)
+		}
+
+		if err := q.sendWithRetry(ctx, batch); err != nil {
+			pushErrors.WithLabelValues(q.name).Inc()
+			if !errors.As(err, new(*pushPermanentError)) {
+				return errors.Join(append(errs, fmt.Errorf("failed to push to %s. %w", q.name, err))...)
+			}
+			logger.Error("dropping batch which can't be pushed", "target", q.name, "error", err.Error())
+			errs = append(errs, fmt.Errorf("failed to push to %s. %w", q.name, err))
+		}
+		q.remove(batch)
+	}
+}
+
+// head returns the oldest queued batch, nil if the queue is empty
+func (q *pushQueue) head() *pushBatch {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.batches) == 0 {
+		return nil
+	}
+	return q.batches[0]
+}
+
+// remove removes the pushed batch from the queue, unless it was dropped (the buffer was full) while
+// it was pushed
+func (q *pushQueue) remove(batch *pushBatch) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.batches) > 0 && q.batches[0] == batch {
+		q.batches = q.batches[1:]
+	}
+	pushBufferedBatches.WithLabelValues(q.name).Set(float64(len(q.batches)))
+}
+
+// notify signals run that a batch was added, without blocking if a flush is still running
+func (q *pushQueue) notify() {
+	select {
+	case q.pending <- struct{}{}:
+	default:
+	}
+}
+
+// run flushes the queue whenever a batch was added until the context is done, so a target which
+// is slow or down doesn't delay the pushes to the other targets
+func (q *pushQueue) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.pending:
+			if err := q.flush(ctx); err != nil {
+				logger.Error("failed to push metrics", "error", err.Error())
+			}
+		}
+	}
+}
+
+// sendWithRetry sends the batch, failed sends are retried with an exponential backoff
+func (q *pushQueue) sendWithRetry(ctx context.Context, batch *pushBatch) error {
+	backoff := q.backoff
+	for attempt := 1; ; attempt++ {
+		sendCtx, cancel := context.WithTimeout(ctx, q.timeout)
+		err := q.target.send(sendCtx, batch)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt > q.retries || errors.As(err, new(*pushPermanentError)) {
+			return err
+		}
+
+		logger.Warn("push failed, retrying", "target", q.name, "attempt", attempt, "backoff", backoff.String(), "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, pushMaxBackoff)
+	}
+}
+
+// pushEnabled returns true if a push target is configured
+func pushEnabled() bool {
+	return opts.pushPushgatewayURL != "" || opts.pushRemoteWriteURL != "" || opts.pushOTLPEndpoint != ""
+}
+
+// newPushQueues returns the queues of the configured push targets, omreport is used for the
+// resource attributes of OTLP
+func newPushQueues(omr *omreport.OMReport) ([]*pushQueue, error) {
+	client := &http.Client{}
+	if opts.pushHTTPConfigFile != "" {
+		cfg, _, err := config.LoadHTTPConfigFile(opts.pushHTTPConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load push http config file %q. %w", opts.pushHTTPConfigFile, err)
+		}
+		if err := cfg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid push http config file %q. %w", opts.pushHTTPConfigFile, err)
+		}
+		client, err = config.NewClientFromConfig(*cfg, "dellhw_exporter_push")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	instance := opts.pushInstance
+	if instance == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hostname for the instance label, set push-instance instead. %w", err)
+		}
+		instance = hostname
+	}
+	grouping := map[string]string{"job": opts.pushJob, "instance": instance}
+	maps.Copy(grouping, opts.pushGrouping)
+	if opts.pushGroupingServiceTag {
+		serviceTag, err := omr.ChassisServiceTag()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chassis service tag for the service_tag label. %w", err)
+		}
+		grouping["service_tag"] = serviceTag
+	}
+
+	queues := []*pushQueue{}
+	newQueue := func(name string, target pushTarget) {
+		queues = append(queues, &pushQueue{
+			name:       name,
+			target:     target,
+			timeout:    opts.pushTimeout,
+			retries:    opts.pushRetries,
+			backoff:    opts.pushRetryBackoff,
+			bufferSize: opts.pushBufferSize,
+			pending:    make(chan struct{}, 1),
+		})
+	}
+	if opts.pushPushgatewayURL != "" {
+		newQueue("pushgateway", &pushgatewayTarget{url: opts.pushPushgatewayURL, grouping: grouping, client: client})
+	}
+	if opts.pushRemoteWriteURL != "" {
+		newQueue("remote_write", &remoteWriteTarget{url: opts.pushRemoteWriteURL, labels: grouping, client: client})
+	}
+	if opts.pushOTLPEndpoint != "" {
+		resource := otlpResource(omr, instance, opts.pushGrouping)
+		target, err := newOTLPTarget(opts.pushOTLPEndpoint, opts.pushOTLPProtocol, client, resource)
+		if err != nil {
+			return nil, err
+		}
+		newQueue("otlp", target)
+	}
+	return queues, nil
+}
+
+// runPush pushes the metrics to the push targets instead of serving them over HTTP
+func (p *program) runPush(gatherer prometheus.Gatherer, queues []*pushQueue) {
+	if opts.pushInterval <= 0 {
+		if err := pushMetrics(context.Background(), gatherer, queues); err != nil {
+			logger.Error("failed to push metrics", "error", err.Error())
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	logger.Info("pushing metrics periodically", "interval", opts.pushInterval.String())
+	for _, q := range queues {
+		go q.run(context.Background())
+	}
+	ticker := time.NewTicker(opts.pushInterval)
+	defer ticker.Stop()
+	for {
+		batch := gatherBatch(gatherer)
+		for _, q := range queues {
+			q.enqueue(batch)
+			q.notify()
+		}
+		<-ticker.C
+	}
+}
+
+// gatherBatch gathers the metrics
+func gatherBatch(gatherer prometheus.Gatherer) *pushBatch {
+	begin := time.Now()
+	families, err := gatherer.Gather()
+	if err != nil {
+		// Like the HTTP handler (promhttp.ContinueOnError) the metrics which could be gathered are pushed
+		logger.Error("error while gathering metrics", "error", err.Error())
+	}
+	return &pushBatch{timestamp: begin, families: families}
+}
+
+// pushMetrics gathers the metrics and pushes them to the targets, the targets are pushed to
+// concurrently
+func pushMetrics(ctx context.Context, gatherer prometheus.Gatherer, queues []*pushQueue) error {
+	batch := gatherBatch(gatherer)
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(queues))
+	for i, q := range queues {
+		q.enqueue(batch)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = q.flush(ctx)
+		}()
+	}
+	wg.Wait()
+	logger.Debug("pushed metrics", "duration", time.Since(batch.timestamp).String())
+	return errors.Join(errs...)
+}
+
+// pushgatewayTarget pushes the metrics to a Pushgateway, replacing the metrics of the group
+type pushgatewayTarget struct {
+	url      string
+	grouping map[string]string
+	client   *http.Client
+}
+
+func (t *pushgatewayTarget) send(ctx context.Context, batch *pushBatch) error {
+	pusher := push.New(t.url, t.grouping["job"]).
+		Client(t.client).
+		Gatherer(prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+			return batch.families, nil
+		}))
+	for _, name := range slices.Sorted(maps.Keys(t.grouping)) {
+		if name != "job" {
+			pusher = pusher.Grouping(name, t.grouping[name])
+		}
+	}
+	return pusher.PushContext(ctx)
+}
+
+// buffered the Pushgateway only keeps the latest metrics of a group
+func (t *pushgatewayTarget) buffered() bool {
+	return false
+}
+
+// remoteWriteTarget sends the metrics as Prometheus remote write (1.0) requests
+type remoteWriteTarget struct {
+	url string
+	// labels are added to all series (if the metric doesn't have the label)
+	labels map[string]string
+	client *http.Client
+}
+
+func (t *remoteWriteTarget) send(ctx context.Context, batch *pushBatch) error {
+	body := snappy.Encode(nil, encodeWriteRequest(batch, t.labels))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return &pushPermanentError{err}
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "dellhw_exporter")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("unexpected status code %d while pushing to %s: %s", resp.StatusCode, t.url, bytes.TrimSpace(msg))
+	// Like Prometheus, only server errors and rate limits are retried
+	if resp.StatusCode/100 != 5 && resp.StatusCode != http.StatusTooManyRequests {
+		return &pushPermanentError{err}
+	}
+	return err
+}
+
+func (t *remoteWriteTarget) buffered() bool {
+	return true
+}
+
+// encodeWriteRequest encodes the batch as a remote write WriteRequest protobuf message, histograms
+// and summaries are split into their series (e.g., _bucket, _sum and _count) like in the text format
+func encodeWriteRequest(batch *pushBatch, labels map[string]string) []byte {
+	timestamp := batch.timestamp.UnixMilli()
+
+	var req []byte
+	appendSeries := func(name string, m *dto.Metric, value float64, extra ...string) {
+		series := map[string]string{}
+		maps.Copy(series, labels)
+		for _, l := range m.GetLabel() {
+			series[l.GetName()] = l.GetValue()
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			series[extra[i]] = extra[i+1]
+		}
+		series["__name__"] = name
+
+		var ts []byte
+		for _, labelName := range slices.Sorted(maps.Keys(series)) {
+			var label []byte
+			label = protowire.AppendTag(label, 1, protowire.BytesType)
+			label = protowire.AppendString(label, labelName)
+			label = protowire.AppendTag(label, 2, protowire.BytesType)
+			label = protowire.AppendString(label, series[labelName])
+			ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, label)
+		}
+
+		var sample []byte
+		sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(timestamp))
+		ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, sample)
+
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendBytes(req, ts)
+	}
+
+	for _, mf := range batch.families {
+		name := mf.GetName()
+		for _, m := range mf.GetMetric() {
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				appendSeries(name, m, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				appendSeries(name, m, m.GetGauge().GetValue())
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				for _, b := range h.GetBucket() {
+					// The +Inf bucket is added below, from the sample count
+					if math.IsInf(b.GetUpperBound(), 1) {
+						continue
+					}
+					appendSeries(name+"_bucket", m, float64(b.GetCumulativeCount()), "le", formatBound(b.GetUpperBound()))
+				}
+				appendSeries(name+"_bucket", m, float64(h.GetSampleCount()), "le", "+Inf")
+				appendSeries(name+"_sum", m, h.GetSampleSum())
+				appendSeries(name+"_count", m, float64(h.GetSampleCount()))
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					appendSeries(name, m, q.GetValue(), "quantile", formatBound(q.GetQuantile()))
+				}
+				appendSeries(name+"_sum", m, s.GetSampleSum())
+				appendSeries(name+"_count", m, float64(s.GetSampleCount()))
+			default:
+				appendSeries(name, m, m.GetUntyped().GetValue())
+			}
+		}
+	}
+	return req
+}
+
+func formatBound(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
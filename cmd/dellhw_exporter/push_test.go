@@ -0,0 +1,396 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// remoteWriteSample is a decoded sample of a remote write request
+type remoteWriteSample struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// decodeWriteRequest decodes the series of a WriteRequest message, each series has one sample
+func decodeWriteRequest(t *testing.T, b []byte) []remoteWriteSample {
+	t.Helper()
+
+	fields := func(b []byte, fn func(num protowire.Number, typ protowire.Type, b []byte) int) {
+		for len(b) > 0 {
+			num, typ, n := protowire.ConsumeTag(b)
+			require.GreaterOrEqual(t, n, 0)
+			b = b[n:]
+			n = fn(num, typ, b)
+			require.GreaterOrEqual(t, n, 0)
+			b = b[n:]
+		}
+	}
+
+	samples := []remoteWriteSample{}
+	fields(b, func(_ protowire.Number, _ protowire.Type, b []byte) int {
+		ts, n := protowire.ConsumeBytes(b)
+		sample := remoteWriteSample{labels: map[string]string{}}
+		fields(ts, func(num protowire.Number, _ protowire.Type, b []byte) int {
+			v, n := protowire.ConsumeBytes(b)
+			switch num {
+			case 1:
+				var name, value string
+				fields(v, func(num protowire.Number, _ protowire.Type, b []byte) int {
+					s, n := protowire.ConsumeString(b)
+					if num == 1 {
+						name = s
+					} else {
+						value = s
+					}
+					return n
+				})
+				sample.labels[name] = value
+			case 2:
+				fields(v, func(num protowire.Number, typ protowire.Type, b []byte) int {
+					if num == 1 {
+						bits, n := protowire.ConsumeFixed64(b)
+						sample.value = math.Float64frombits(bits)
+						return n
+					}
+					ts, n := protowire.ConsumeVarint(b)
+					sample.timestamp = int64(ts)
+					return n
+				})
+			}
+			return n
+		})
+		samples = append(samples, sample)
+		return n
+	})
+	return samples
+}
+
+// remoteWriteReceiver records the samples of the remote write requests, the first failures
+// requests are answered with the status
+type remoteWriteReceiver struct {
+	mu       sync.Mutex
+	failures int
+	status   int
+	requests [][]remoteWriteSample
+}
+
+func (r *remoteWriteReceiver) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		assert.Equal(t, "snappy", req.Header.Get("Content-Encoding"))
+		assert.Equal(t, "0.1.0", req.Header.Get("X-Prometheus-Remote-Write-Version"))
+
+		if r.failures > 0 {
+			r.failures--
+			http.Error(w, "unavailable", r.status)
+			return
+		}
+
+		compressed, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		body, err := snappy.Decode(nil, compressed)
+		require.NoError(t, err)
+		r.requests = append(r.requests, decodeWriteRequest(t, body))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestPushRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewDellHWCollector(map[string]collector.Collector{
+		"ok": &testCollector{name: "ok"},
+	}, false, 0))
+	return reg
+}
+
+func newTestPushQueue(name string, target pushTarget) *pushQueue {
+	return &pushQueue{
+		name:       name,
+		target:     target,
+		timeout:    time.Second,
+		retries:    2,
+		backoff:    time.Millisecond,
+		bufferSize: 2,
+		pending:    make(chan struct{}, 1),
+	}
+}
+
+// blockingTarget is a pushTarget which doesn't return until unblocked, like an endpoint which
+// doesn't respond
+type blockingTarget struct {
+	unblock chan struct{}
+	sent    chan *pushBatch
+}
+
+func (t *blockingTarget) send(ctx context.Context, batch *pushBatch) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.unblock:
+	}
+	t.sent <- batch
+	return nil
+}
+
+func (t *blockingTarget) buffered() bool {
+	return true
+}
+
+func TestEncodeWriteRequest(t *testing.T) {
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "test_seconds",
+		Help:    "Test histogram.",
+		Buckets: []float64{0.5, 1},
+	})
+	histogram.Observe(0.7)
+	reg := newTestPushRegistry()
+	reg.MustRegister(histogram)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	batch := &pushBatch{timestamp: time.UnixMilli(1700000000000), families: families}
+
+	samples := decodeWriteRequest(t, encodeWriteRequest(batch, map[string]string{"job": "dellhw_exporter", "instance": "host1"}))
+
+	series := map[string]float64{}
+	for _, s := range samples {
+		assert.Equal(t, int64(1700000000000), s.timestamp)
+		assert.Equal(t, "dellhw_exporter", s.labels["job"])
+		assert.Equal(t, "host1", s.labels["instance"])
+		key := s.labels["__name__"]
+		for _, name := range []string{"collector", "le"} {
+			if v, ok := s.labels[name]; ok {
+				key += " " + name + "=" + v
+			}
+		}
+		series[key] = s.value
+	}
+	assert.Equal(t, map[string]float64{
+		"dell_hw_scrape_collector_success collector=ok":          1,
+		"dell_hw_scrape_collector_duration_seconds collector=ok": series["dell_hw_scrape_collector_duration_seconds collector=ok"],
+		"dell_hw_test_value collector=ok":                        1,
+		"test_seconds_bucket le=0.5":                             0,
+		"test_seconds_bucket le=1":                               1,
+		"test_seconds_bucket le=+Inf":                            1,
+		"test_seconds_sum":                                       0.7,
+		"test_seconds_count":                                     1,
+	}, series)
+}
+
+func TestEncodeWriteRequestInfBucket(t *testing.T) {
+	// A histogram can contain the +Inf bucket, e.g., a const histogram or one gathered from elsewhere
+	desc := prometheus.NewDesc("test_seconds", "Test histogram.", nil, nil)
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.CollectorFunc(func(ch chan<- prometheus.Metric) {
+		ch <- prometheus.MustNewConstHistogram(desc, 2, 1.5, map[float64]uint64{1: 1, math.Inf(1): 2})
+	}))
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	samples := decodeWriteRequest(t, encodeWriteRequest(&pushBatch{timestamp: time.UnixMilli(1700000000000), families: families}, nil))
+	buckets := map[string]float64{}
+	for _, s := range samples {
+		if s.labels["__name__"] == "test_seconds_bucket" {
+			assert.NotContains(t, buckets, s.labels["le"], "duplicate bucket")
+			buckets[s.labels["le"]] = s.value
+		}
+	}
+	assert.Equal(t, map[string]float64{"1": 1, "+Inf": 2}, buckets)
+}
+
+func TestPushRemoteWriteRetryAndBuffer(t *testing.T) {
+	receiver := &remoteWriteReceiver{failures: 3, status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(receiver.handler(t))
+	defer server.Close()
+
+	q := newTestPushQueue("remote_write", &remoteWriteTarget{url: server.URL, labels: map[string]string{"job": "dellhw_exporter"}, client: server.Client()})
+	reg := newTestPushRegistry()
+
+	// The first push fails after all retries, the batch is buffered
+	errorsBefore := testutil.ToFloat64(pushErrors.WithLabelValues("remote_write"))
+	assert.ErrorContains(t, pushMetrics(context.Background(), reg, []*pushQueue{q}), "503")
+	assert.Equal(t, 1.0, testutil.ToFloat64(pushErrors.WithLabelValues("remote_write"))-errorsBefore)
+	assert.Len(t, q.batches, 1)
+	assert.Empty(t, receiver.requests)
+
+	// The buffered batch is pushed (after a retry) before the new batch
+	require.NoError(t, pushMetrics(context.Background(), reg, []*pushQueue{q}))
+	assert.Empty(t, q.batches)
+	assert.Equal(t, 0.0, testutil.ToFloat64(pushBufferedBatches.WithLabelValues("remote_write")))
+	require.Len(t, receiver.requests, 2)
+	assert.LessOrEqual(t, receiver.requests[0][0].timestamp, receiver.requests[1][0].timestamp)
+}
+
+func TestPushQueueBuffer(t *testing.T) {
+	receiver := &remoteWriteReceiver{failures: math.MaxInt, status: http.StatusBadGateway}
+	server := httptest.NewServer(receiver.handler(t))
+	defer server.Close()
+
+	q := newTestPushQueue("remote_write", &remoteWriteTarget{url: server.URL, client: server.Client()})
+	q.retries = 0
+
+	// Only the newest batches are kept if the buffer is full
+	for i := range 3 {
+		q.enqueue(&pushBatch{timestamp: time.UnixMilli(int64(i))})
+		assert.Error(t, q.flush(context.Background()))
+	}
+	require.Len(t, q.batches, 2)
+	assert.Equal(t, int64(1), q.batches[0].timestamp.UnixMilli())
+	assert.Equal(t, int64(2), q.batches[1].timestamp.UnixMilli())
+
+	// A batch which is rejected is dropped instead of retried
+	receiver.failures, receiver.status = 1, http.StatusBadRequest
+	err := q.flush(context.Background())
+	assert.ErrorContains(t, err, "400")
+	assert.Empty(t, q.batches)
+	assert.Len(t, receiver.requests, 1)
+}
+
+func TestPushPushgateway(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	var bodies []string
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if failures > 0 {
+			failures--
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		paths = append(paths, req.Method+" "+req.URL.Path)
+		bodies = append(bodies, string(body))
+	}))
+	defer server.Close()
+
+	q := newTestPushQueue("pushgateway", &pushgatewayTarget{
+		url:      server.URL,
+		grouping: map[string]string{"job": "dellhw_exporter", "instance": "host1", "datacenter": "dc1"},
+		client:   server.Client(),
+	})
+	q.retries = 0
+
+	reg := newTestPushRegistry()
+	assert.Error(t, pushMetrics(context.Background(), reg, []*pushQueue{q}))
+	// Only the latest batch is pushed to the Pushgateway
+	require.NoError(t, pushMetrics(context.Background(), reg, []*pushQueue{q}))
+	assert.Empty(t, q.batches)
+
+	// The order of the grouping labels in the path doesn't matter
+	require.Len(t, paths, 1)
+	method, path, _ := strings.Cut(paths[0], " ")
+	assert.Equal(t, http.MethodPut, method)
+	segments := strings.Split(strings.TrimPrefix(path, "/metrics/"), "/")
+	grouping := map[string]string{}
+	for i := 0; i+1 < len(segments); i += 2 {
+		grouping[segments[i]] = segments[i+1]
+	}
+	assert.Equal(t, map[string]string{"job": "dellhw_exporter", "instance": "host1", "datacenter": "dc1"}, grouping)
+	require.Len(t, bodies, 1)
+	assert.Contains(t, bodies[0], "dell_hw_test_value")
+}
+
+func TestPushQueuesIndependent(t *testing.T) {
+	slow := &blockingTarget{unblock: make(chan struct{}), sent: make(chan *pushBatch, 10)}
+	fast := &blockingTarget{unblock: make(chan struct{}), sent: make(chan *pushBatch, 10)}
+	close(fast.unblock)
+	slowQueue := newTestPushQueue("slow", slow)
+	fastQueue := newTestPushQueue("fast", fast)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, q := range []*pushQueue{slowQueue, fastQueue} {
+		go q.run(ctx)
+	}
+
+	// The batches are pushed to the fast target while the push to the slow target hangs
+	for i := range 2 {
+		batch := &pushBatch{timestamp: time.UnixMilli(int64(i))}
+		for _, q := range []*pushQueue{slowQueue, fastQueue} {
+			q.enqueue(batch)
+			q.notify()
+		}
+		select {
+		case sent := <-fast.sent:
+			assert.Equal(t, batch, sent)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "batch wasn't pushed to the fast target")
+		}
+	}
+
+	// The batches queued for the slow target are pushed, oldest first, once it responds
+	close(slow.unblock)
+	for i := range 2 {
+		select {
+		case sent := <-slow.sent:
+			assert.Equal(t, int64(i), sent.timestamp.UnixMilli())
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "batch wasn't pushed to the slow target")
+		}
+	}
+}
+
+func TestNewPushQueuesServiceTag(t *testing.T) {
+	opts.pushPushgatewayURL = "http://pushgateway:9091"
+	opts.pushInstance = "host1"
+	opts.pushGroupingServiceTag = true
+	defer func() {
+		opts.pushPushgatewayURL, opts.pushInstance, opts.pushGroupingServiceTag = "", "", false
+	}()
+
+	omr := &omreport.OMReport{
+		Options: &omreport.Options{},
+		Reader:  omreport.NewFixtureReader("../../collector/testdata/omreport"),
+	}
+	queues, err := newPushQueues(omr)
+	require.NoError(t, err)
+	require.Len(t, queues, 1)
+	target, ok := queues[0].target.(*pushgatewayTarget)
+	require.True(t, ok)
+	assert.Equal(t, "123XXX", target.grouping["service_tag"])
+	assert.Equal(t, "host1", target.grouping["instance"])
+
+	// The push isn't started without the service tag, the metrics of hosts would overwrite each other
+	omr.Reader = omreport.NewFixtureReader(t.TempDir())
+	_, err = newPushQueues(omr)
+	assert.ErrorContains(t, err, "service tag")
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"maps"
+	"net/http"
+	"os/exec"
+	"slices"
+	"time"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omsa"
+	"github.com/prometheus/common/version"
+)
+
+// omsaVersionRetryInterval is how long a failure to get the OMSA version is shown before omreport is run again
+const omsaVersionRetryInterval = time.Minute
+
+//go:embed status.html
+var statusPageTemplate string
+
+var statusPage = template.Must(template.New("status").Funcs(template.FuncMap{
+	"statusClass": statusClass,
+	"formatTime": func(t time.Time) string {
+		return t.Format("2006-01-02 15:04:05 MST")
+	},
+	"seconds": func(d time.Duration) string {
+		return d.Round(time.Millisecond).String()
+	},
+}).Parse(statusPageTemplate))
+
+// statusPageData is the data of the status page
+type statusPageData struct {
+	Version       string
+	MetricsPath   string
+	InventoryPath string
+	StatusPath    string
+
+	// Collected is false until the first collection, the status is only known afterwards
+	Collected  bool
+	Status     string
+	Problems   []statusPageComponent
+	Collectors []statusPageCollector
+	Cache      statusPageCache
+
+	OMReportPath     string
+	OMReportError    string
+	OMSAVersion      string
+	OMSAVersionError string
+	// OMSAServices are the checked OMSA services, empty if the preflight check is disabled
+	OMSAServices []omsa.ServiceStatus
+}
+
+// statusPageComponent is a component which isn't ok
+type statusPageComponent struct {
+	Collector string
+	Component string
+	Status    string
+	State     string
+}
+
+// statusPageCollector is an enabled collector with its last run and the number of components
+type statusPageCollector struct {
+	Name          string
+	Ran           bool
+	LastRun       time.Time
+	Duration      time.Duration
+	Error         string
+	LastError     string
+	LastErrorTime time.Time
+	Components    int
+	Problems      int
+}
+
+// statusPageCache is the state of the metrics cache
+type statusPageCache struct {
+	Enabled     bool
+	Duration    time.Duration
+	LastCollect time.Time
+	ValidUntil  time.Time
+	Valid       bool
+}
+
+// statusClass returns the CSS class of a status
+func statusClass(status string) string {
+	switch status {
+	case "Ok":
+		return "ok"
+	case "Non-Critical":
+		return "warning"
+	case "Critical", "Non-Recoverable":
+		return "critical"
+	}
+	return "unknown"
+}
+
+// omsaVersion returns the version of OMSA, omreport is only run until the version is known and at
+// most once per omsaVersionRetryInterval while it fails
+func (p *program) omsaVersion() (string, error) {
+	p.omsaVersionMutex.Lock()
+	defer p.omsaVersionMutex.Unlock()
+
+	if p.omsaVersionCache != "" {
+		return p.omsaVersionCache, nil
+	}
+	if p.omsaVersionErr != nil && time.Since(p.omsaVersionTime) < omsaVersionRetryInterval {
+		return "", p.omsaVersionErr
+	}
+	v, err := p.omr.OMSAVersion()
+	p.omsaVersionCache, p.omsaVersionErr, p.omsaVersionTime = v, err, time.Now()
+	if err != nil {
+		return "", err
+	}
+	return v, nil
+}
+
+// statusPageData returns the data of the status page, the collectors aren't run and the OMSA services
+// aren't checked for it
+func (p *program) statusPageData() *statusPageData {
+	runs := p.collector.lastRuns()
+	report := p.runsReport(runs)
+
+	data := &statusPageData{
+		Version:       version.Info(),
+		MetricsPath:   opts.metricsPath,
+		InventoryPath: apiInventoryPath,
+		StatusPath:    apiStatusPath,
+		Collected:     len(runs) > 0,
+		Status:        report.Status,
+		OMReportPath:  p.omr.Options.OMReportExecutable,
+	}
+
+	components := map[string]int{}
+	problems := map[string]int{}
+	for _, component := range report.Components {
+		components[component.Collector]++
+		if component.Status != "" && component.Status != "Ok" {
+			problems[component.Collector]++
+			data.Problems = append(data.Problems, statusPageComponent{
+				Collector: component.Collector,
+				Component: component.labelsString(),
+				Status:    component.Status,
+				State:     component.State,
+			})
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(p.collector.collectors)) {
+		c := statusPageCollector{
+			Name:       name,
+			Components: components[name],
+			Problems:   problems[name],
+		}
+		if run, ok := runs[name]; ok {
+			c.Ran = true
+			c.LastRun = run.time
+			c.Duration = run.duration
+			if run.err != nil {
+				c.Error = run.err.Error()
+			} else if err, ok := report.Errors[name]; ok {
+				c.Error = err
+			}
+			if run.lastErr != nil {
+				c.LastError = run.lastErr.Error()
+				c.LastErrorTime = run.lastErrTime
+			}
+		}
+		data.Collectors = append(data.Collectors, c)
+	}
+
+	data.Cache = statusPageCache{
+		Enabled:     p.collector.cachingEnabled,
+		Duration:    p.collector.cacheDuration,
+		LastCollect: p.collector.lastCollect(),
+	}
+	if data.Cache.Enabled && !data.Cache.LastCollect.IsZero() {
+		data.Cache.ValidUntil = data.Cache.LastCollect.Add(data.Cache.Duration)
+		data.Cache.Valid = time.Now().Before(data.Cache.ValidUntil)
+	}
+
+	if _, err := exec.LookPath(data.OMReportPath); err != nil {
+		data.OMReportError = err.Error()
+	} else if v, err := p.omsaVersion(); err != nil {
+		data.OMSAVersionError = err.Error()
+	} else {
+		data.OMSAVersion = v
+	}
+	if p.collector.preflight != nil {
+		data.OMSAServices = p.collector.lastOMSAServices()
+	}
+
+	return data
+}
+
+// handleStatusPage renders the status page
+func (p *program) handleStatusPage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := statusPage.Execute(buf, p.statusPageData()); err != nil {
+		logger.Error("failed to render status page", "error", err.Error())
+		http.Error(w, "failed to render status page", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getStatusPage(t *testing.T, p *program, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	p.handleStatusPage(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestStatusPage(t *testing.T) {
+	executable := filepath.Join(t.TempDir(), "omreport")
+	require.NoError(t, os.WriteFile(executable, []byte("#!/bin/sh\n"), 0o755))
+
+	failing := &testCollector{name: "failing", err: errors.New("omreport failed")}
+	p := &program{
+		collector: NewDellHWCollector(map[string]collector.Collector{
+			"ok":      &testCollector{name: "ok"},
+			"failing": failing,
+		}, true, 60),
+		omr: &omreport.OMReport{
+			Options: &omreport.Options{OMReportExecutable: executable},
+			Reader:  omreport.NewFixtureReader("../../collector/testdata/omreport"),
+		},
+	}
+
+	rec := getStatusPage(t, p, "/")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Nothing was collected yet.")
+	assert.Contains(t, rec.Body.String(), "<td>Not run yet</td>")
+
+	// The last error is shown after the collector succeeded again
+	collectAndCount(t, p.collector)
+	failing.err = nil
+	p.collector.lastCollectTime = p.collector.lastCollectTime.Add(-p.collector.cacheDuration)
+	collectAndCount(t, p.collector)
+
+	body := getStatusPage(t, p, "/").Body.String()
+	assert.Contains(t, body, `Overall status: <span class="status ok">Ok</span>`)
+	assert.Contains(t, body, "All components are ok.")
+	assert.Contains(t, body, "<td>failing</td>")
+	assert.Contains(t, body, ": omreport failed</td>")
+	assert.Contains(t, body, "<tr><th>Caching</th><td>Enabled</td></tr>")
+	assert.Contains(t, body, "<tr><th>Duration</th><td>1m0s</td></tr>")
+	assert.Contains(t, body, "<tr><th>Path</th><td>"+executable+"</td></tr>")
+	assert.Contains(t, body, "<tr><th>OMSA Version</th><td>9.5.0</td></tr>")
+
+	assert.Equal(t, http.StatusNotFound, getStatusPage(t, p, "/unknown").Code)
+}
+
+func TestStatusPageOMSAVersionError(t *testing.T) {
+	runs := 0
+	p := &program{
+		omr: &omreport.OMReport{
+			Reader: func(f func(omreport.Output), mode omreport.ReaderMode, cmd string, args ...string) error {
+				runs++
+				return errors.New("omreport failed")
+			},
+		},
+	}
+
+	// The failure is reused, omreport isn't run for every page load
+	for range 3 {
+		_, err := p.omsaVersion()
+		assert.EqualError(t, err, "omreport failed")
+	}
+	assert.Equal(t, 1, runs)
+
+	p.omsaVersionTime = p.omsaVersionTime.Add(-omsaVersionRetryInterval)
+	_, err := p.omsaVersion()
+	assert.Error(t, err)
+	assert.Equal(t, 2, runs)
+}
+
+func TestStatusPageProblems(t *testing.T) {
+	p := newAPIProgram(t, nil)
+	p.omr.Options = &omreport.Options{OMReportExecutable: filepath.Join(t.TempDir(), "missing")}
+	collectAndCount(t, p.collector)
+
+	body := getStatusPage(t, p, "/").Body.String()
+	assert.Contains(t, body, `Overall status: <span class="status critical">Critical</span>`)
+	assert.Contains(t, body, "<td>controller=0 disk=0_1_0</td>")
+	assert.Contains(t, body, `<td class="critical">No: omreport failed</td>`)
+	assert.Contains(t, body, "<tr><th>Error</th>")
+}
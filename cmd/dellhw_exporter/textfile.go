@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// runTextfile writes the metrics to the textfile instead of serving them over HTTP
+func (p *program) runTextfile(gatherer prometheus.Gatherer) {
+	if filepath.Ext(opts.outputTextfile) != ".prom" {
+		logger.Warn("the node_exporter textfile collector only reads files ending with .prom", "output_textfile", opts.outputTextfile)
+	}
+
+	if opts.outputTextfileInterval <= 0 {
+		if err := writeTextfile(gatherer, opts.outputTextfile); err != nil {
+			logger.Error("failed to write textfile", "output_textfile", opts.outputTextfile, "error", err.Error())
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	logger.Info("writing textfile periodically", "output_textfile", opts.outputTextfile, "interval", opts.outputTextfileInterval.String())
+	ticker := time.NewTicker(opts.outputTextfileInterval)
+	defer ticker.Stop()
+	for {
+		if err := writeTextfile(gatherer, opts.outputTextfile); err != nil {
+			logger.Error("failed to write textfile", "output_textfile", opts.outputTextfile, "error", err.Error())
+		}
+		<-ticker.C
+	}
+}
+
+// writeTextfile gathers the metrics and writes them to the file, the file is replaced atomically
+// so the node_exporter never reads a partially written file
+func writeTextfile(gatherer prometheus.Gatherer, path string) error {
+	begin := time.Now()
+	if err := prometheus.WriteToTextfile(path, gatherer); err != nil {
+		return err
+	}
+	logger.Debug("wrote textfile", "output_textfile", path, "duration", time.Since(begin).String())
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTextfile(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewDellHWCollector(map[string]collector.Collector{
+		"ok": &testCollector{name: "ok"},
+	}, false, 0))
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dellhw.prom")
+	require.NoError(t, writeTextfile(reg, path))
+	// Overwriting the file works as well
+	require.NoError(t, writeTextfile(reg, path))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	mfs, err := parser.TextToMetricFamilies(f)
+	require.NoError(t, err)
+	assert.Contains(t, mfs, "dell_hw_test_value")
+	assert.Contains(t, mfs, "dell_hw_scrape_collector_success")
+	for name := range mfs {
+		assert.True(t, strings.HasPrefix(name, "dell_hw_"), "unexpected metric %s in textfile", name)
+	}
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	assert.Error(t, writeTextfile(reg, filepath.Join(dir, "does-not-exist", "dellhw.prom")))
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/galexrt/dellhw_exporter/collector"
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+)
+
+// runZabbixCommand prints the components of a kind as Zabbix low-level discovery JSON
+func runZabbixCommand(args []string) int {
+	zabbixFlags := newCommandFlags("zabbix <kind>")
+	if err := parseFlagsAndEnvVars(zabbixFlags, args); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to parse flags and env vars. %v\n", err)
+		return 1
+	}
+	if zabbixFlags.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "exactly one kind must be given, one of %s\n", strings.Join(formatKinds(), ", "))
+		return 1
+	}
+
+	sources, err := formatSourcesOf(zabbixFlags.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err := writeZabbix(os.Stdout, newFormatBackend(), sources[0]); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to discover %s. %v\n", sources[0].kind, err)
+		return 1
+	}
+	return 0
+}
+
+// writeZabbix writes the components of the source as a JSON array, the labels are the LLD macros
+// (e.g., {#CONTROLLER}) and the status, state and values are included so the discovery item can
+// also be the master item of dependent items
+func writeZabbix(w io.Writer, b collector.Backend, source formatSource) error {
+	endCycle := omreport.StartCycle()
+	defer endCycle()
+
+	components, err := formatComponents(b, source)
+	if err != nil {
+		return err
+	}
+
+	discovery := make([]map[string]any, 0, len(components))
+	for _, c := range components {
+		entry := map[string]any{}
+		for name, value := range c.Labels {
+			entry["{#"+strings.ToUpper(name)+"}"] = value
+		}
+		if c.Status != "" {
+			entry["status"] = c.Status
+		}
+		if c.State != "" {
+			entry["state"] = c.State
+		}
+
+		values := map[string]any{}
+		for metric, value := range c.Attributes {
+			values[metric] = value
+		}
+		for metric, value := range c.Readings {
+			values[metric] = value
+		}
+		if len(values) > 0 {
+			entry["values"] = values
+		}
+
+		discovery = append(discovery, entry)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(discovery)
+}
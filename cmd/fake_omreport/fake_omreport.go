@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// fake_omreport is a stand-in for the omreport executable which serves captured
+// outputs from a fixture directory, to run the exporter without Dell hardware.
+//
+// It is configured through environment variables, as the exporter only passes the omreport arguments:
+//
+//	FAKE_OMREPORT_FIXTURES_DIR  Directory with the fixture files (default "collector/testdata/omreport"),
+//	                            see omreport.FixtureFileName for the naming of the files.
+//	FAKE_OMREPORT_DELAY         Delay added to every command, e.g., "2s".
+//	FAKE_OMREPORT_FAULTS        Comma separated list of faults per command, "<command>=<fault>". The command
+//	                            is the fixture name without the ".txt" suffix (e.g., "storage_pdisk_controller=0")
+//	                            or "*" for all commands. Faults: "delay:<duration>", "exit:<code>" (e.g., "exit:255"),
+//	                            "garbage" and "hang".
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+)
+
+const (
+	defaultFixturesDir = "collector/testdata/omreport"
+
+	garbageOutput = "\x00\x01;;;garbage;\n;;;\n\xff\xfe random noise;\nSEVERITY\n;\n"
+)
+
+// fault is an injected misbehavior for a command
+type fault struct {
+	delay    time.Duration
+	exitCode int
+	garbage  bool
+	hang     bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	// The exporter always requests the semicolon separated output format
+	i := slices.Index(args, "-fmt")
+	if i == -1 || i+1 >= len(args) || args[i+1] != "ssv" {
+		fmt.Fprintln(os.Stdout, "Error! fake_omreport only supports the \"-fmt ssv\" output format.")
+		return 1
+	}
+	args = slices.Delete(args, i, i+2)
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stdout, "Error! Incomplete command.")
+		return 1
+	}
+
+	command := strings.TrimSuffix(omreport.FixtureFileName(args...), ".txt")
+
+	faults, err := parseFaults(os.Getenv("FAKE_OMREPORT_FAULTS"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error! Invalid FAKE_OMREPORT_FAULTS. %v\n", err)
+		return 1
+	}
+	f := faults["*"]
+	if cf, ok := faults[command]; ok {
+		f = cf
+	}
+
+	if delay := os.Getenv("FAKE_OMREPORT_DELAY"); delay != "" {
+		d, err := time.ParseDuration(delay)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error! Invalid FAKE_OMREPORT_DELAY. %v\n", err)
+			return 1
+		}
+		time.Sleep(d)
+	}
+	time.Sleep(f.delay)
+
+	if f.hang {
+		// Sleep "forever", until the exporter interrupts or kills the process
+		time.Sleep(time.Duration(math.MaxInt64))
+	}
+	if f.garbage {
+		fmt.Fprint(os.Stdout, garbageOutput)
+		return 0
+	}
+	if f.exitCode != 0 {
+		return f.exitCode
+	}
+
+	dir := os.Getenv("FAKE_OMREPORT_FIXTURES_DIR")
+	if dir == "" {
+		dir = defaultFixturesDir
+	}
+	out, err := os.ReadFile(filepath.Join(dir, omreport.FixtureFileName(args...)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stdout, "Error! Invalid command: %s\n", strings.Join(args, " "))
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "Error! Failed to read fixture. %v\n", err)
+		return 1
+	}
+
+	os.Stdout.Write(out)
+	return 0
+}
+
+// parseFaults parses the list of faults, e.g., "chassis_temps=exit:255,storage_vdisk=hang"
+func parseFaults(s string) (map[string]fault, error) {
+	faults := map[string]fault{}
+	if s == "" {
+		return faults, nil
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		// The command can contain "=" itself (e.g., "storage_pdisk_controller=0")
+		i := strings.LastIndex(entry, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid fault %q, expected \"<command>=<fault>\"", entry)
+		}
+		command, spec := strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+
+		f := faults[command]
+		kind, value, _ := strings.Cut(spec, ":")
+		switch kind {
+		case "delay":
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid delay for command %q. %w", command, err)
+			}
+			f.delay = d
+		case "exit":
+			code, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid exit code for command %q. %w", command, err)
+			}
+			f.exitCode = code
+		case "garbage":
+			f.garbage = true
+		case "hang":
+			f.hang = true
+		default:
+			return nil, fmt.Errorf("unknown fault %q for command %q", spec, command)
+		}
+		faults[command] = f
+	}
+
+	return faults, nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fakeOMReport string
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "fake_omreport")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fakeOMReport = filepath.Join(dir, "omreport")
+	if runtime.GOOS == "windows" {
+		fakeOMReport += ".exe"
+	}
+	if out, err := exec.Command("go", "build", "-o", fakeOMReport, ".").CombinedOutput(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to build fake omreport. %v\n%s", err, out)
+		os.RemoveAll(dir)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func newOMReport(t *testing.T, faults string) *omreport.OMReport {
+	t.Setenv("FAKE_OMREPORT_FIXTURES_DIR", filepath.Join("..", "..", "collector", "testdata", "omreport"))
+	t.Setenv("FAKE_OMREPORT_FAULTS", faults)
+
+	return omreport.New(&omreport.Options{
+		OMReportExecutable: fakeOMReport,
+	}).WithCommandTimeout(5 * time.Second)
+}
+
+func TestParseFaults(t *testing.T) {
+	faults, err := parseFaults("storage_pdisk_controller=0=exit:255, chassis_temps=delay:1s,chassis_temps=garbage,*=hang")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]fault{
+		"storage_pdisk_controller=0": {exitCode: 255},
+		"chassis_temps":              {delay: time.Second, garbage: true},
+		"*":                          {hang: true},
+	}, faults)
+
+	for _, invalid := range []string{"chassis_temps", "=hang", "chassis_temps=delay:abc", "chassis_temps=exit:abc", "chassis_temps=explode"} {
+		_, err := parseFaults(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestFakeOMReport(t *testing.T) {
+	or := newOMReport(t, "")
+
+	values, err := or.Temps()
+	require.NoError(t, err)
+	assert.NotEmpty(t, values)
+
+	values, err = or.StoragePdisk("0")
+	require.NoError(t, err)
+	assert.NotEmpty(t, values)
+
+	// Unknown commands are reported as an error by omreport
+	values, err = or.StoragePdisk("42")
+	assert.Error(t, err)
+	assert.Empty(t, values)
+}
+
+func TestFakeOMReportExitCode(t *testing.T) {
+	or := newOMReport(t, "storage_pdisk_controller=0=exit:255,chassis_temps=exit:2")
+
+	// Exit code 255 means no devices have been found
+	values, err := or.StoragePdisk("0")
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+
+	values, err = or.Temps()
+	assert.Error(t, err)
+	assert.Empty(t, values)
+
+	// Other commands are not affected
+	values, err = or.Fans()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, values)
+}
+
+func TestFakeOMReportGarbage(t *testing.T) {
+	or := newOMReport(t, "*=garbage")
+
+	values, err := or.Temps()
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+
+	values, err = or.Fans()
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func TestFakeOMReportTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupting processes is not supported on windows")
+	}
+
+	or := newOMReport(t, "chassis_temps=hang,chassis_fans=delay:100ms").WithCommandTimeout(500 * time.Millisecond)
+
+	start := time.Now()
+	values, err := or.Temps()
+	assert.ErrorIs(t, err, omreport.ErrTimeout)
+	assert.Empty(t, values)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// A delay below the timeout still returns the output
+	values, err = or.Fans()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, values)
+}
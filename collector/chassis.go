@@ -23,6 +23,7 @@ import (
 )
 
 type chassisCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewChassisCollector returns a new chassisCollector
 func NewChassisCollector(cfg *Config) (Collector, error) {
-	return &chassisCollector{}, nil
+	return &chassisCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *chassisCollector) Update(ch chan<- prometheus.Metric) error {
-	chassis, err := or.Chassis()
+	chassis, err := c.backend.Chassis()
 	if err != nil {
 		return err
 	}
@@ -18,12 +18,12 @@ package collector
 
 import (
 	"strconv"
-	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type chassisBatteriesCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -33,12 +33,14 @@ func init() {
 
 // NewChassisBatteriesCollector returns a new chassisBatteriesCollector
 func NewChassisBatteriesCollector(cfg *Config) (Collector, error) {
-	return &chassisBatteriesCollector{}, nil
+	return &chassisBatteriesCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *chassisBatteriesCollector) Update(ch chan<- prometheus.Metric) error {
-	chassisBatteries, err := or.ChassisBatteries()
+	chassisBatteries, err := c.backend.ChassisBatteries()
 	if err != nil {
 		return err
 	}
@@ -58,13 +60,8 @@ func (c *chassisBatteriesCollector) Update(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
-// IsAvailable if the collector is available
+// IsAvailable if the collector is available, it isn't if omreport reports that there are no battery probes
 func (c *chassisBatteriesCollector) IsAvailable() bool {
-	_, err := or.ChassisBatteries()
-	if err == nil {
-		return true
-	}
-
-	e := strings.ToLower(err.Error())
-	return strings.Contains(e, "no battery probes found on this system")
+	_, err := c.backend.ChassisBatteries()
+	return err == nil || !notAvailable(err)
 }
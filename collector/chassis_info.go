@@ -21,6 +21,7 @@ import (
 )
 
 type chassisInfoCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -30,12 +31,14 @@ func init() {
 
 // NewChassisCollector returns a new chassisInfoCollector
 func NewChassisInfoCollector(cfg *Config) (Collector, error) {
-	return &chassisInfoCollector{}, nil
+	return &chassisInfoCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *chassisInfoCollector) Update(ch chan<- prometheus.Metric) error {
-	chassisInfo, err := or.ChassisInfo()
+	chassisInfo, err := c.backend.ChassisInfo()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type chassisRemovableFlashMediaCollector struct {
+	backend Backend
+	current *prometheus.Desc
+}
+
+func init() {
+	Factories["chassis_removable_flash_media"] = NewChassisRemovableFlashMediaCollector
+}
+
+// NewChassisRemovableFlashMediaCollector returns a new chassisRemovableFlashMediaCollector
+func NewChassisRemovableFlashMediaCollector(cfg *Config) (Collector, error) {
+	return &chassisRemovableFlashMediaCollector{
+		backend: cfg.Backend,
+	}, nil
+}
+
+// Update Prometheus metrics
+func (c *chassisRemovableFlashMediaCollector) Update(ch chan<- prometheus.Metric) error {
+	removableFlashMedia, err := c.backend.ChassisRemovableFlashMedia()
+	if err != nil {
+		return err
+	}
+	for _, value := range removableFlashMedia {
+		float, err := strconv.ParseFloat(value.Value, 64)
+		if err != nil {
+			return err
+		}
+		c.current = prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "", value.Name),
+			"Status and redundancy of the internal SD module (IDSDM) and vFlash media.",
+			nil, value.Labels)
+		ch <- prometheus.MustNewConstMetric(
+			c.current, prometheus.GaugeValue, float)
+	}
+
+	return nil
+}
+
+// IsAvailable if the collector is available
+func (c *chassisRemovableFlashMediaCollector) IsAvailable() bool {
+	removableFlashMedia, err := c.backend.ChassisRemovableFlashMedia()
+	return err == nil && len(removableFlashMedia) > 0
+}
@@ -19,6 +19,8 @@ package collector
 import (
 	"io"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/galexrt/dellhw_exporter/pkg/omreport"
 	"github.com/prometheus/client_golang/prometheus"
@@ -27,15 +29,55 @@ import (
 // Namespace holds the metrics namespace/first part
 const Namespace = "dell_hw"
 
-var (
-	or     *omreport.OMReport
-	logger = slog.New(slog.NewTextHandler(io.Discard, nil))
-)
+var logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 
 type Config struct {
+	// Backend provides the hardware data to the collectors
+	Backend       Backend
 	MonitoredNICs []string
+
+	// StatusBackend provides the hardware data to the collectors derived from the status of the
+	// components (health, status_changes), it must return the status with the
+	// omreport.SeverityModelOrdered and without state sets, Backend is used if nil
+	StatusBackend Backend
+	// HealthRules of the health collector, DefaultHealthRules are used if nil
+	HealthRules HealthRules
+	// StatusChangesStateFile is the file the status_changes collector persists the tracked status
+	// to, so the changes survive restarts of the exporter (not persisted if empty)
+	StatusChangesStateFile string
+}
+
+// Backend is the interface a hardware data source has to implement, e.g., omreport.OMReport.
+type Backend interface {
+	Chassis() ([]omreport.Value, error)
+	ChassisBatteries() ([]omreport.Value, error)
+	ChassisBios() ([]omreport.Value, error)
+	ChassisFirmware() ([]omreport.Value, error)
+	ChassisFrontPanel() ([]omreport.Value, error)
+	ChassisHWPerformance() ([]omreport.Value, error)
+	ChassisInfo() ([]omreport.Value, error)
+	ChassisIntrusion() ([]omreport.Value, error)
+	ChassisPwrManagement() ([]omreport.Value, error)
+	ChassisRemovableFlashMedia() ([]omreport.Value, error)
+	ChassisSlots() ([]omreport.Value, error)
+	Fans() ([]omreport.Value, error)
+	Memory() ([]omreport.Value, error)
+	Nics(nicList ...string) ([]omreport.Value, error)
+	Processors() ([]omreport.Value, error)
+	Ps() ([]omreport.Value, error)
+	PsAmpsSysboardPwr() ([]omreport.Value, error)
+	StorageBattery() ([]omreport.Value, error)
+	StorageController() ([]omreport.Value, error)
+	StorageEnclosure() ([]omreport.Value, error)
+	StoragePdisk(cid string) ([]omreport.Value, error)
+	StorageVdisk() ([]omreport.Value, error)
+	System() ([]omreport.Value, error)
+	Temps() ([]omreport.Value, error)
+	Volts() ([]omreport.Value, error)
 }
 
+var _ Backend = &omreport.OMReport{}
+
 // Factories contains the list of all available collectors.
 var Factories = make(map[string]func(*Config) (Collector, error))
 
@@ -50,12 +92,38 @@ type IsAvailable interface {
 	IsAvailable() bool
 }
 
-// SetOMReport a given OMReport for the collectors
-func SetOMReport(omrep *omreport.OMReport) {
-	or = omrep
+// inventoryMetric is a metric which is only used for the inventory (e.g., the JSON API) and not exposed
+type inventoryMetric struct {
+	prometheus.Metric
+}
+
+// InventoryOnly returns true if the metric is only used for the inventory (e.g., the serial numbers of the
+// disks for the JSON API) and not exposed, it would add a series with a label per component
+func InventoryOnly(m prometheus.Metric) bool {
+	_, ok := m.(inventoryMetric)
+	return ok
+}
+
+// notAvailableErrors are the (lower case) errors of omreport if the hardware isn't present on the system
+var notAvailableErrors = []string{
+	"no battery probes found on this system",
+}
+
+// notAvailable returns true if the error of omreport means that the hardware isn't present on the system
+func notAvailable(err error) bool {
+	e := strings.ToLower(err.Error())
+	return slices.ContainsFunc(notAvailableErrors, func(s string) bool {
+		return strings.Contains(e, s)
+	})
 }
 
 // SetLogger
 func SetLogger(l *slog.Logger) {
 	logger = l
 }
+
+// Derived is implemented by collectors which derive their metrics from the data of the other
+// collectors, they are run after all other collectors have finished.
+type Derived interface {
+	Derived()
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden .prom files in testdata/")
+
+// collectorAdapter wraps a Collector so it can be registered in a prometheus registry
+type collectorAdapter struct {
+	t         *testing.T
+	collector Collector
+}
+
+// Describe implements the prometheus.Collector interface, no descriptions are sent
+// as the collectors create their descriptions dynamically.
+func (a collectorAdapter) Describe(ch chan<- *prometheus.Desc) {}
+
+// Collect implements the prometheus.Collector interface.
+func (a collectorAdapter) Collect(ch chan<- prometheus.Metric) {
+	updateCh := make(chan prometheus.Metric)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for metric := range updateCh {
+			// Like the exporter, the inventory only metrics aren't exposed
+			if !InventoryOnly(metric) {
+				ch <- metric
+			}
+		}
+	}()
+
+	err := a.collector.Update(updateCh)
+	close(updateCh)
+	<-done
+	if err != nil {
+		a.t.Errorf("collector update failed: %v", err)
+	}
+}
+
+func newFixtureBackend() Backend {
+	return &omreport.OMReport{
+		Reader: omreport.NewFixtureReader(filepath.Join("testdata", "omreport")),
+	}
+}
+
+// newFixtureStatusBackend returns the fixture backend with the severity model used by the status derived collectors
+func newFixtureStatusBackend() Backend {
+	return &omreport.OMReport{
+		Options: &omreport.Options{SeverityModel: omreport.SeverityModelOrdered, SkipAbsent: true},
+		Reader:  omreport.NewFixtureReader(filepath.Join("testdata", "omreport")),
+	}
+}
+
+func gatherCollector(t *testing.T, c Collector) *prometheus.Registry {
+	t.Helper()
+
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(collectorAdapter{t: t, collector: c})
+	return reg
+}
+
+func TestCollectors(t *testing.T) {
+	backend := newFixtureBackend()
+	statusBackend := newFixtureStatusBackend()
+
+	for name, factory := range Factories {
+		t.Run(name, func(t *testing.T) {
+			c, err := factory(&Config{Backend: backend, StatusBackend: statusBackend})
+			require.NoError(t, err)
+
+			reg := gatherCollector(t, c)
+			golden := filepath.Join("testdata", name+".prom")
+
+			if *update {
+				mfs, err := reg.Gather()
+				require.NoError(t, err)
+
+				buf := &bytes.Buffer{}
+				enc := expfmt.NewEncoder(buf, expfmt.NewFormat(expfmt.TypeTextPlain))
+				for _, mf := range mfs {
+					require.NoError(t, enc.Encode(mf))
+				}
+				require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+			}
+
+			f, err := os.Open(golden)
+			require.NoError(t, err)
+			defer f.Close()
+
+			assert.NoError(t, testutil.GatherAndCompare(reg, f))
+		})
+	}
+}
+
+func TestStoragePdiskStateSets(t *testing.T) {
+	// There is one storage_controller_status value per state, the pdisks are only read once per controller
+	backend := &omreport.OMReport{
+		Options: &omreport.Options{StateSets: true},
+		Reader:  omreport.NewFixtureReader(filepath.Join("testdata", "omreport")),
+	}
+	pdisks, err := backend.StoragePdisk("0")
+	require.NoError(t, err)
+
+	c, err := NewStoragePdiskCollector(&Config{Backend: backend})
+	require.NoError(t, err)
+	count, err := testutil.GatherAndCount(gatherCollector(t, c))
+	require.NoError(t, err)
+	infos := slices.DeleteFunc(slices.Clone(pdisks), func(v omreport.Value) bool { return v.Name != "storage_pdisk_info" })
+	assert.Equal(t, len(pdisks)-len(infos), count)
+
+	values, err := AllStoragePdisks(backend)
+	require.NoError(t, err)
+	assert.Equal(t, pdisks, values)
+}
+
+func TestStoragePdiskInventoryOnly(t *testing.T) {
+	backend := NewFakeBackend()
+	backend.Values["StorageController"] = []omreport.Value{{Name: "storage_controller_status", Value: "0", Labels: map[string]string{"id": "0"}}}
+	backend.Values["StoragePdisk"] = []omreport.Value{
+		{Name: "storage_pdisk_status", Value: "0", Labels: map[string]string{"controller": "0", "disk": "0_1_0"}},
+		{Name: "storage_pdisk_info", Value: "0", Labels: map[string]string{"controller": "0", "disk": "0_1_0", "serial_number": "BTHC643503A2200TGN"}},
+	}
+
+	c, err := NewStoragePdiskCollector(&Config{Backend: backend})
+	require.NoError(t, err)
+	ch := make(chan prometheus.Metric, 10)
+	require.NoError(t, c.Update(ch))
+	close(ch)
+
+	inventoryOnly := []bool{}
+	for metric := range ch {
+		inventoryOnly = append(inventoryOnly, InventoryOnly(metric))
+	}
+	// The serial number is only for the inventory, it isn't exposed
+	assert.Equal(t, []bool{false, true}, inventoryOnly)
+}
+
+func TestCollectorsBackendError(t *testing.T) {
+	backend := &omreport.OMReport{
+		Reader: omreport.NewFixtureReader(filepath.Join("testdata", "does-not-exist")),
+	}
+
+	for name, factory := range Factories {
+		// The health collector reports the subsystems as unknown and the status_changes
+		// collector keeps the last known status instead of failing
+		if name == "version" || name == "health" || name == "status_changes" {
+			continue
+		}
+
+		t.Run(name, func(t *testing.T) {
+			c, err := factory(&Config{Backend: backend})
+			require.NoError(t, err)
+
+			ch := make(chan prometheus.Metric, 100)
+			err = c.Update(ch)
+			close(ch)
+			assert.ErrorIs(t, err, os.ErrNotExist)
+			assert.Empty(t, ch)
+		})
+	}
+}
+
+func TestCollectorInvalidValue(t *testing.T) {
+	backend := NewFakeBackend()
+	backend.Values["Temps"] = []omreport.Value{
+		{Name: "chassis_temps", Value: "", Labels: map[string]string{"component": "System_Board_Inlet_Temp"}},
+	}
+
+	c, err := NewTempsCollector(&Config{Backend: backend})
+	require.NoError(t, err)
+
+	ch := make(chan prometheus.Metric, 1)
+	assert.Error(t, c.Update(ch))
+}
+
+func TestIsAvailable(t *testing.T) {
+	available := NewFakeBackend()
+	available.Values["ChassisIntrusion"] = []omreport.Value{
+		{Name: "chassis_intrusion_status", Value: "0", Labels: map[string]string{"probe": "System_Board_Intrusion"}},
+	}
+	unavailable := NewFakeBackend()
+	unavailable.Errors["ChassisBatteries"] = errors.New("No battery probes found on this system")
+
+	c, err := NewChassisIntrusionCollector(&Config{Backend: available})
+	require.NoError(t, err)
+	assert.True(t, c.(IsAvailable).IsAvailable())
+
+	c, err = NewChassisIntrusionCollector(&Config{Backend: unavailable})
+	require.NoError(t, err)
+	assert.False(t, c.(IsAvailable).IsAvailable())
+
+	c, err = NewChassisBatteriesCollector(&Config{Backend: unavailable})
+	require.NoError(t, err)
+	assert.False(t, c.(IsAvailable).IsAvailable())
+
+	c, err = NewChassisBatteriesCollector(&Config{Backend: available})
+	require.NoError(t, err)
+	assert.True(t, c.(IsAvailable).IsAvailable())
+}
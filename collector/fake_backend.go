@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"slices"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+)
+
+// FakeBackend is a Backend returning static values, e.g., to test collectors without Dell hardware.
+// Values and Errors are keyed by the Backend method name (e.g., "Chassis"), for StoragePdisk only
+// the values with a matching "controller" label are returned.
+type FakeBackend struct {
+	Values map[string][]omreport.Value
+	Errors map[string]error
+}
+
+var _ Backend = &FakeBackend{}
+
+// NewFakeBackend returns a new FakeBackend
+func NewFakeBackend() *FakeBackend {
+	return &FakeBackend{
+		Values: map[string][]omreport.Value{},
+		Errors: map[string]error{},
+	}
+}
+
+func (b *FakeBackend) get(method string) ([]omreport.Value, error) {
+	if err := b.Errors[method]; err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(b.Values[method]), nil
+}
+
+// Chassis returns the values set for Chassis
+func (b *FakeBackend) Chassis() ([]omreport.Value, error) {
+	return b.get("Chassis")
+}
+
+// ChassisBatteries returns the values set for ChassisBatteries
+func (b *FakeBackend) ChassisBatteries() ([]omreport.Value, error) {
+	return b.get("ChassisBatteries")
+}
+
+// ChassisBios returns the values set for ChassisBios
+func (b *FakeBackend) ChassisBios() ([]omreport.Value, error) {
+	return b.get("ChassisBios")
+}
+
+// ChassisFirmware returns the values set for ChassisFirmware
+func (b *FakeBackend) ChassisFirmware() ([]omreport.Value, error) {
+	return b.get("ChassisFirmware")
+}
+
+// ChassisFrontPanel returns the values set for ChassisFrontPanel
+func (b *FakeBackend) ChassisFrontPanel() ([]omreport.Value, error) {
+	return b.get("ChassisFrontPanel")
+}
+
+// ChassisHWPerformance returns the values set for ChassisHWPerformance
+func (b *FakeBackend) ChassisHWPerformance() ([]omreport.Value, error) {
+	return b.get("ChassisHWPerformance")
+}
+
+// ChassisInfo returns the values set for ChassisInfo
+func (b *FakeBackend) ChassisInfo() ([]omreport.Value, error) {
+	return b.get("ChassisInfo")
+}
+
+// ChassisIntrusion returns the values set for ChassisIntrusion
+func (b *FakeBackend) ChassisIntrusion() ([]omreport.Value, error) {
+	return b.get("ChassisIntrusion")
+}
+
+// ChassisPwrManagement returns the values set for ChassisPwrManagement
+func (b *FakeBackend) ChassisPwrManagement() ([]omreport.Value, error) {
+	return b.get("ChassisPwrManagement")
+}
+
+// ChassisRemovableFlashMedia returns the values set for ChassisRemovableFlashMedia
+func (b *FakeBackend) ChassisRemovableFlashMedia() ([]omreport.Value, error) {
+	return b.get("ChassisRemovableFlashMedia")
+}
+
+// ChassisSlots returns the values set for ChassisSlots
+func (b *FakeBackend) ChassisSlots() ([]omreport.Value, error) {
+	return b.get("ChassisSlots")
+}
+
+// Fans returns the values set for Fans
+func (b *FakeBackend) Fans() ([]omreport.Value, error) {
+	return b.get("Fans")
+}
+
+// Memory returns the values set for Memory
+func (b *FakeBackend) Memory() ([]omreport.Value, error) {
+	return b.get("Memory")
+}
+
+// Nics returns the values set for Nics, filtered by the "device" label if a nic list is given
+func (b *FakeBackend) Nics(nicList ...string) ([]omreport.Value, error) {
+	values, err := b.get("Nics")
+	if err != nil || len(nicList) == 0 {
+		return values, err
+	}
+
+	return slices.DeleteFunc(values, func(v omreport.Value) bool {
+		return !slices.Contains(nicList, v.Labels["device"])
+	}), nil
+}
+
+// Processors returns the values set for Processors
+func (b *FakeBackend) Processors() ([]omreport.Value, error) {
+	return b.get("Processors")
+}
+
+// Ps returns the values set for Ps
+func (b *FakeBackend) Ps() ([]omreport.Value, error) {
+	return b.get("Ps")
+}
+
+// PsAmpsSysboardPwr returns the values set for PsAmpsSysboardPwr
+func (b *FakeBackend) PsAmpsSysboardPwr() ([]omreport.Value, error) {
+	return b.get("PsAmpsSysboardPwr")
+}
+
+// StorageBattery returns the values set for StorageBattery
+func (b *FakeBackend) StorageBattery() ([]omreport.Value, error) {
+	return b.get("StorageBattery")
+}
+
+// StorageController returns the values set for StorageController
+func (b *FakeBackend) StorageController() ([]omreport.Value, error) {
+	return b.get("StorageController")
+}
+
+// StorageEnclosure returns the values set for StorageEnclosure
+func (b *FakeBackend) StorageEnclosure() ([]omreport.Value, error) {
+	return b.get("StorageEnclosure")
+}
+
+// StoragePdisk returns the values set for StoragePdisk which belong to the given controller
+func (b *FakeBackend) StoragePdisk(cid string) ([]omreport.Value, error) {
+	values, err := b.get("StoragePdisk")
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(values, func(v omreport.Value) bool {
+		return v.Labels["controller"] != cid
+	}), nil
+}
+
+// StorageVdisk returns the values set for StorageVdisk
+func (b *FakeBackend) StorageVdisk() ([]omreport.Value, error) {
+	return b.get("StorageVdisk")
+}
+
+// System returns the values set for System
+func (b *FakeBackend) System() ([]omreport.Value, error) {
+	return b.get("System")
+}
+
+// Temps returns the values set for Temps
+func (b *FakeBackend) Temps() ([]omreport.Value, error) {
+	return b.get("Temps")
+}
+
+// Volts returns the values set for Volts
+func (b *FakeBackend) Volts() ([]omreport.Value, error) {
+	return b.get("Volts")
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeBackend(t *testing.T) {
+	b := NewFakeBackend()
+	b.Values["Nics"] = []omreport.Value{
+		{Name: "nic_status", Value: "0", Labels: map[string]string{"id": "0", "device": "eno1"}},
+		{Name: "nic_status", Value: "1", Labels: map[string]string{"id": "1", "device": "eno2"}},
+	}
+	b.Values["StoragePdisk"] = []omreport.Value{
+		{Name: "storage_pdisk_status", Value: "0", Labels: map[string]string{"controller": "0", "disk": "0_1_0"}},
+		{Name: "storage_pdisk_status", Value: "0", Labels: map[string]string{"controller": "1", "disk": "0_1_0"}},
+	}
+	b.Errors["Temps"] = errors.New("failed")
+
+	nics, err := b.Nics()
+	assert.NoError(t, err)
+	assert.Len(t, nics, 2)
+
+	nics, err = b.Nics("eno2")
+	assert.NoError(t, err)
+	assert.Len(t, nics, 1)
+	assert.Equal(t, "eno2", nics[0].Labels["device"])
+	// Filtering must not modify the values of the backend
+	assert.Len(t, b.Values["Nics"], 2)
+
+	pdisks, err := b.StoragePdisk("1")
+	assert.NoError(t, err)
+	assert.Len(t, pdisks, 1)
+	assert.Equal(t, "1", pdisks[0].Labels["controller"])
+
+	_, err = b.Temps()
+	assert.Error(t, err)
+
+	chassis, err := b.Chassis()
+	assert.NoError(t, err)
+	assert.Empty(t, chassis)
+}
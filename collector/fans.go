@@ -23,6 +23,7 @@ import (
 )
 
 type fansCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewFansCollector returns a new fansCollector
 func NewFansCollector(cfg *Config) (Collector, error) {
-	return &fansCollector{}, nil
+	return &fansCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *fansCollector) Update(ch chan<- prometheus.Metric) error {
-	fans, err := or.Fans()
+	fans, err := c.backend.Fans()
 	if err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ import (
 )
 
 type firmwaresCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,16 +33,18 @@ func init() {
 
 // NewFirmwaresCollector returns a new firmwaresCollector
 func NewFirmwaresCollector(cfg *Config) (Collector, error) {
-	return &firmwaresCollector{}, nil
+	return &firmwaresCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *firmwaresCollector) Update(ch chan<- prometheus.Metric) error {
-	chassisBios, err := or.ChassisBios()
+	chassisBios, err := c.backend.ChassisBios()
 	if err != nil {
 		return err
 	}
-	chassisFirmware, err := or.ChassisFirmware()
+	chassisFirmware, err := c.backend.ChassisFirmware()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.yaml.in/yaml/v2"
+)
+
+const (
+	// HealthOverallSubsystem is the subsystem with the worst health of all subsystems
+	HealthOverallSubsystem = "overall"
+
+	// healthUnknown is the "Unknown" value of the omreport.SeverityModelOrdered, used when the
+	// status of a subsystem couldn't be collected
+	healthUnknown = 1
+)
+
+// HealthRules maps the subsystems of the health rollup to the status metrics they consist of,
+// e.g., "storage" to "storage_pdisk_status" and "storage_vdisk_status"
+type HealthRules map[string][]string
+
+// DefaultHealthRules are used when no health rules are configured
+var DefaultHealthRules = HealthRules{
+	"chassis":    {"chassis_status", "system_status"},
+	"cooling":    {"chassis_fan_status", "chassis_temps"},
+	"memory":     {"chassis_memory_status"},
+	"power":      {"ps_status", "chassis_volts_status", "cmos_batteries_status"},
+	"processors": {"chassis_processor_status"},
+	"storage":    {"storage_battery_status", "storage_controller_status", "storage_enclosure_status", "storage_pdisk_status", "storage_vdisk_status"},
+}
+
+// LoadHealthRules loads the health rules from a YAML file, which maps the subsystems to a list of status metrics
+func LoadHealthRules(path string) (HealthRules, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := HealthRules{}
+	if err := yaml.UnmarshalStrict(content, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse health rules file %q. %w", path, err)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid health rules file %q. %w", path, err)
+	}
+
+	return rules, nil
+}
+
+// Validate checks that the rules only contain known status metrics
+func (r HealthRules) Validate() error {
+	known := map[string]bool{}
+	for _, source := range statusSources {
+		for _, metric := range source.metrics {
+			known[metric] = true
+		}
+	}
+
+	for subsystem, metrics := range r {
+		if subsystem == HealthOverallSubsystem {
+			return fmt.Errorf("subsystem %q is reserved for the overall health", HealthOverallSubsystem)
+		}
+		if len(metrics) == 0 {
+			return fmt.Errorf("subsystem %q has no status metrics", subsystem)
+		}
+		for _, metric := range metrics {
+			if !known[metric] {
+				return fmt.Errorf("subsystem %q contains unknown status metric %q (must be one of %v)", subsystem, metric, slices.Sorted(maps.Keys(known)))
+			}
+		}
+	}
+
+	return nil
+}
+
+type healthCollector struct {
+	backend Backend
+	rules   HealthRules
+	current *prometheus.Desc
+}
+
+func init() {
+	Factories["health"] = NewHealthCollector
+}
+
+// NewHealthCollector returns a new healthCollector
+func NewHealthCollector(cfg *Config) (Collector, error) {
+	rules := cfg.HealthRules
+	if rules == nil {
+		rules = DefaultHealthRules
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &healthCollector{
+		backend: cfg.statusBackend(),
+		rules:   rules,
+	}, nil
+}
+
+// Derived the health is derived from the status metrics of the other collectors
+func (c *healthCollector) Derived() {}
+
+// Update Prometheus metrics
+func (c *healthCollector) Update(ch chan<- prometheus.Metric) error {
+	needed := map[string]bool{}
+	for _, metrics := range c.rules {
+		for _, metric := range metrics {
+			needed[metric] = true
+		}
+	}
+
+	// Worst status per metric, metrics which couldn't be collected are unknown and metrics of hardware
+	// which isn't present (e.g., no battery probes) are left out
+	worst := map[string]float64{}
+	for _, source := range statusSources {
+		if !slices.ContainsFunc(source.metrics, func(metric string) bool { return needed[metric] }) {
+			continue
+		}
+
+		values, err := source.values(c.backend)
+		if err != nil && notAvailable(err) {
+			logger.Debug("hardware not present, leaving it out of the health rollup", "metrics", source.metrics, "error", err.Error())
+			continue
+		}
+		if err != nil {
+			logger.Error("failed to collect status for health rollup", "metrics", source.metrics, "error", err.Error())
+			for _, metric := range source.metrics {
+				worst[metric] = healthUnknown
+			}
+			continue
+		}
+
+		for _, value := range values {
+			if !needed[value.Name] {
+				continue
+			}
+			float, err := strconv.ParseFloat(value.Value, 64)
+			if err != nil {
+				return err
+			}
+			worst[value.Name] = max(worst[value.Name], float)
+		}
+	}
+
+	c.current = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "health", "status"),
+		"Worst status of the components per subsystem (0 Ok, 1 Unknown, 2 Non-Critical, 3 Critical, 4 Non-Recoverable).",
+		[]string{"subsystem"}, nil)
+
+	overall := 0.0
+	for subsystem, metrics := range c.rules {
+		health := 0.0
+		for _, metric := range metrics {
+			health = max(health, worst[metric])
+		}
+		overall = max(overall, health)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.current, prometheus.GaugeValue, health, subsystem)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.current, prometheus.GaugeValue, overall, HealthOverallSubsystem)
+
+	return nil
+}
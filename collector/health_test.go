@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCollector(t *testing.T) {
+	backend := NewFakeBackend()
+	backend.Values["Fans"] = []omreport.Value{
+		{Name: "chassis_fan_status", Value: "0", Labels: map[string]string{"fan": "Fan1"}},
+		{Name: "chassis_fan_status", Value: "2", Labels: map[string]string{"fan": "Fan2"}},
+	}
+	backend.Values["StorageController"] = []omreport.Value{
+		{Name: "storage_controller_status", Value: "0", Labels: map[string]string{"id": "0"}},
+		{Name: "storage_controller_status", Value: "0", Labels: map[string]string{"id": "1"}},
+	}
+	backend.Values["StoragePdisk"] = []omreport.Value{
+		{Name: "storage_pdisk_status", Value: "0", Labels: map[string]string{"controller": "0", "disk": "0_1_0"}},
+		{Name: "storage_pdisk_status", Value: "3", Labels: map[string]string{"controller": "1", "disk": "0_1_0"}},
+		// Other values of a source are ignored
+		{Name: "storage_pdisk_state", Value: "9", Labels: map[string]string{"controller": "1", "disk": "0_1_0"}},
+	}
+	backend.Errors["Memory"] = errors.New("failed")
+
+	c, err := NewHealthCollector(&Config{
+		StatusBackend: backend,
+		HealthRules: HealthRules{
+			"cooling": {"chassis_fan_status", "chassis_temps"},
+			"memory":  {"chassis_memory_status"},
+			"storage": {"storage_controller_status", "storage_pdisk_status"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Implements(t, (*Derived)(nil), c)
+
+	expected := `# HELP dell_hw_health_status Worst status of the components per subsystem (0 Ok, 1 Unknown, 2 Non-Critical, 3 Critical, 4 Non-Recoverable).
+# TYPE dell_hw_health_status gauge
+dell_hw_health_status{subsystem="cooling"} 2
+dell_hw_health_status{subsystem="memory"} 1
+dell_hw_health_status{subsystem="overall"} 3
+dell_hw_health_status{subsystem="storage"} 3
+`
+	assert.NoError(t, testutil.GatherAndCompare(gatherCollector(t, c), strings.NewReader(expected)))
+}
+
+func TestHealthCollectorNotAvailable(t *testing.T) {
+	backend := NewFakeBackend()
+	backend.Values["Ps"] = []omreport.Value{
+		{Name: "ps_status", Value: "0", Labels: map[string]string{"id": "0"}},
+	}
+	backend.Errors["ChassisBatteries"] = errors.New("No battery probes found on this system")
+
+	c, err := NewHealthCollector(&Config{Backend: backend})
+	require.NoError(t, err)
+
+	// The host without battery probes is healthy
+	expected := `# HELP dell_hw_health_status Worst status of the components per subsystem (0 Ok, 1 Unknown, 2 Non-Critical, 3 Critical, 4 Non-Recoverable).
+# TYPE dell_hw_health_status gauge
+dell_hw_health_status{subsystem="chassis"} 0
+dell_hw_health_status{subsystem="cooling"} 0
+dell_hw_health_status{subsystem="memory"} 0
+dell_hw_health_status{subsystem="overall"} 0
+dell_hw_health_status{subsystem="power"} 0
+dell_hw_health_status{subsystem="processors"} 0
+dell_hw_health_status{subsystem="storage"} 0
+`
+	assert.NoError(t, testutil.GatherAndCompare(gatherCollector(t, c), strings.NewReader(expected)))
+
+	// Other errors are still unknown
+	backend.Errors["ChassisBatteries"] = errors.New("failed")
+	expected = strings.NewReplacer(`"overall"} 0`, `"overall"} 1`, `"power"} 0`, `"power"} 1`).Replace(expected)
+	assert.NoError(t, testutil.GatherAndCompare(gatherCollector(t, c), strings.NewReader(expected)))
+}
+
+func TestHealthCollectorDefaultRules(t *testing.T) {
+	c, err := NewHealthCollector(&Config{Backend: NewFakeBackend()})
+	require.NoError(t, err)
+
+	count, err := testutil.GatherAndCount(gatherCollector(t, c), "dell_hw_health_status")
+	require.NoError(t, err)
+	assert.Equal(t, len(DefaultHealthRules)+1, count)
+}
+
+func TestHealthRulesValidate(t *testing.T) {
+	assert.NoError(t, DefaultHealthRules.Validate())
+
+	for name, rules := range map[string]HealthRules{
+		"reserved subsystem": {HealthOverallSubsystem: {"chassis_status"}},
+		"no metrics":         {"chassis": {}},
+		"unknown metric":     {"chassis": {"chassis_status", "chassis_info"}},
+	} {
+		assert.Error(t, rules.Validate(), name)
+
+		_, err := NewHealthCollector(&Config{Backend: NewFakeBackend(), HealthRules: rules})
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLoadHealthRules(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("disks:\n  - storage_pdisk_status\n  - storage_vdisk_status\npower: [ps_status]\n"), 0o644))
+	rules, err := LoadHealthRules(path)
+	require.NoError(t, err)
+	assert.Equal(t, HealthRules{
+		"disks": {"storage_pdisk_status", "storage_vdisk_status"},
+		"power": {"ps_status"},
+	}, rules)
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("power: ps_status\n"), 0o644))
+	_, err = LoadHealthRules(invalid)
+	assert.Error(t, err)
+
+	unknown := filepath.Join(dir, "unknown.yaml")
+	require.NoError(t, os.WriteFile(unknown, []byte("power: [ps_amps]\n"), 0o644))
+	_, err = LoadHealthRules(unknown)
+	assert.Error(t, err)
+
+	_, err = LoadHealthRules(filepath.Join(dir, "does-not-exist.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
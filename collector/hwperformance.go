@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type hwPerformanceCollector struct {
+	backend Backend
+	current *prometheus.Desc
+}
+
+func init() {
+	Factories["hwperformance"] = NewHWPerformanceCollector
+}
+
+// NewHWPerformanceCollector returns a new hwPerformanceCollector
+func NewHWPerformanceCollector(cfg *Config) (Collector, error) {
+	return &hwPerformanceCollector{
+		backend: cfg.Backend,
+	}, nil
+}
+
+// Update Prometheus metrics
+func (c *hwPerformanceCollector) Update(ch chan<- prometheus.Metric) error {
+	hwPerformance, err := c.backend.ChassisHWPerformance()
+	if err != nil {
+		return err
+	}
+	for _, value := range hwPerformance {
+		float, err := strconv.ParseFloat(value.Value, 64)
+		if err != nil {
+			return err
+		}
+		c.current = prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "", value.Name),
+			"Hardware performance degradation status and cause.",
+			nil, value.Labels)
+		ch <- prometheus.MustNewConstMetric(
+			c.current, prometheus.GaugeValue, float)
+	}
+
+	return nil
+}
+
+// IsAvailable if the collector is available
+func (c *hwPerformanceCollector) IsAvailable() bool {
+	hwPerformance, err := c.backend.ChassisHWPerformance()
+	return err == nil && len(hwPerformance) > 0
+}
@@ -23,6 +23,7 @@ import (
 )
 
 type memoryCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewMemoryCollector returns a new memoryCollector
 func NewMemoryCollector(cfg *Config) (Collector, error) {
-	return &memoryCollector{}, nil
+	return &memoryCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *memoryCollector) Update(ch chan<- prometheus.Metric) error {
-	memory, err := or.Memory()
+	memory, err := c.backend.Memory()
 	if err != nil {
 		return err
 	}
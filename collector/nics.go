@@ -23,6 +23,7 @@ import (
 )
 
 type nicsCollector struct {
+	backend Backend
 	current *prometheus.Desc
 	nicList []string
 }
@@ -34,13 +35,14 @@ func init() {
 // NewNicsCollector returns a new nicsCollector
 func NewNicsCollector(cfg *Config) (Collector, error) {
 	return &nicsCollector{
+		backend: cfg.Backend,
 		nicList: cfg.MonitoredNICs,
 	}, nil
 }
 
 // Update Prometheus metrics
 func (c *nicsCollector) Update(ch chan<- prometheus.Metric) error {
-	nics, err := or.Nics(c.nicList...)
+	nics, err := c.backend.Nics(c.nicList...)
 	if err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ import (
 )
 
 type processorsCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewProcessorsCollector returns a new processorsCollector
 func NewProcessorsCollector(cfg *Config) (Collector, error) {
-	return &processorsCollector{}, nil
+	return &processorsCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *processorsCollector) Update(ch chan<- prometheus.Metric) error {
-	chassis, err := or.Processors()
+	chassis, err := c.backend.Processors()
 	if err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ import (
 )
 
 type psCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewPsCollector returns new psCollector
 func NewPsCollector(cfg *Config) (Collector, error) {
-	return &psCollector{}, nil
+	return &psCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *psCollector) Update(ch chan<- prometheus.Metric) error {
-	ps, err := or.Ps()
+	ps, err := c.backend.Ps()
 	if err != nil {
 		return err
 	}
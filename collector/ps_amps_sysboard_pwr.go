@@ -23,6 +23,7 @@ import (
 )
 
 type psAmpsSysboardPwrCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewPsAmpsSysboardPwrCollector returns a new psAmpsSysboardPwrCollector
 func NewPsAmpsSysboardPwrCollector(cfg *Config) (Collector, error) {
-	return &psAmpsSysboardPwrCollector{}, nil
+	return &psAmpsSysboardPwrCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *psAmpsSysboardPwrCollector) Update(ch chan<- prometheus.Metric) error {
-	psampssysboardpwr, err := or.PsAmpsSysboardPwr()
+	psampssysboardpwr, err := c.backend.PsAmpsSysboardPwr()
 	if err != nil {
 		return err
 	}
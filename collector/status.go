@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+)
+
+// statusSource is a backend method and the status (and state) metrics it returns
+type statusSource struct {
+	metrics []string
+	states  []string
+	values  func(b Backend) ([]omreport.Value, error)
+}
+
+// statusSources are the sources of the status metrics used by the collectors derived from the status
+var statusSources = []statusSource{
+	{[]string{"chassis_status"}, nil, Backend.Chassis},
+	{[]string{"chassis_fan_status"}, nil, Backend.Fans},
+	{[]string{"chassis_memory_status"}, nil, Backend.Memory},
+	{[]string{"chassis_processor_status"}, nil, Backend.Processors},
+	{[]string{"chassis_temps"}, nil, Backend.Temps},
+	{[]string{"chassis_volts_status"}, nil, Backend.Volts},
+	{[]string{"chassis_intrusion_status"}, nil, Backend.ChassisIntrusion},
+	{[]string{"chassis_removable_flash_media_status", "chassis_sd_card_status", "chassis_vflash_status"}, nil, Backend.ChassisRemovableFlashMedia},
+	{[]string{"cmos_batteries_status"}, nil, Backend.ChassisBatteries},
+	{[]string{"ps_status"}, nil, Backend.Ps},
+	{[]string{"system_status"}, nil, Backend.System},
+	{[]string{"storage_battery_status"}, nil, Backend.StorageBattery},
+	{[]string{"storage_controller_status"}, nil, Backend.StorageController},
+	{[]string{"storage_enclosure_status"}, nil, Backend.StorageEnclosure},
+	{[]string{"storage_pdisk_status"}, []string{"storage_pdisk_state"}, AllStoragePdisks},
+	{[]string{"storage_vdisk_status"}, []string{"storage_vdisk_state"}, Backend.StorageVdisk},
+}
+
+// AllStoragePdisks returns the pdisks of all controllers of the backend
+func AllStoragePdisks(b Backend) ([]omreport.Value, error) {
+	controllers, err := storageControllerIDs(b)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []omreport.Value{}
+	for _, cid := range controllers {
+		pdisks, err := b.StoragePdisk(cid)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, pdisks...)
+	}
+	return values, nil
+}
+
+// statusBackend returns the StatusBackend or the Backend if no StatusBackend is set
+func (cfg *Config) statusBackend() Backend {
+	if cfg.StatusBackend != nil {
+		return cfg.StatusBackend
+	}
+	return cfg.Backend
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// statusChange is the tracked status (or state) of a component
+type statusChange struct {
+	Metric     string            `json:"metric"`
+	Labels     map[string]string `json:"labels"`
+	Value      string            `json:"value"`
+	Changes    uint64            `json:"changes"`
+	LastChange time.Time         `json:"last_change,omitzero"`
+}
+
+// key identifies the component by the metric name and labels
+func (s *statusChange) key() string {
+	key := s.Metric
+	for _, name := range slices.Sorted(maps.Keys(s.Labels)) {
+		key += "\xff" + name + "=" + s.Labels[name]
+	}
+	return key
+}
+
+type statusChangesCollector struct {
+	backend   Backend
+	stateFile string
+	now       func() time.Time
+
+	// mu guards the components as concurrent scrapes can update them at the same time
+	mu         sync.Mutex
+	components map[string]*statusChange
+
+	changes    *prometheus.Desc
+	lastChange *prometheus.Desc
+}
+
+func init() {
+	Factories["status_changes"] = NewStatusChangesCollector
+}
+
+// NewStatusChangesCollector returns a new statusChangesCollector
+func NewStatusChangesCollector(cfg *Config) (Collector, error) {
+	c := &statusChangesCollector{
+		backend:    cfg.statusBackend(),
+		stateFile:  cfg.StatusChangesStateFile,
+		now:        time.Now,
+		components: map[string]*statusChange{},
+	}
+
+	if c.stateFile != "" {
+		if err := c.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Derived the status changes are derived from the status metrics of the other collectors
+func (c *statusChangesCollector) Derived() {}
+
+// Update Prometheus metrics
+func (c *statusChangesCollector) Update(ch chan<- prometheus.Metric) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	changed := false
+	seen := map[string]bool{}
+	// Components of sources which couldn't be collected are kept with their last known status
+	collected := map[string]bool{}
+
+	for _, source := range statusSources {
+		values, err := source.values(c.backend)
+		if err != nil {
+			logger.Debug("failed to collect status for status changes", "metrics", source.metrics, "error", err.Error())
+			continue
+		}
+
+		tracked := slices.Concat(source.metrics, source.states)
+		for _, metric := range tracked {
+			collected[metric] = true
+		}
+
+		for _, value := range values {
+			if !slices.Contains(tracked, value.Name) {
+				continue
+			}
+
+			current := &statusChange{
+				Metric: value.Name,
+				Labels: maps.Clone(value.Labels),
+				Value:  value.Value,
+			}
+			key := current.key()
+			seen[key] = true
+
+			previous, ok := c.components[key]
+			if !ok {
+				c.components[key] = current
+				changed = true
+				continue
+			}
+			if previous.Value != current.Value {
+				logger.Debug("status changed", "metric", value.Name, "labels", value.Labels, "previous", previous.Value, "current", current.Value)
+				previous.Value = current.Value
+				previous.Changes++
+				previous.LastChange = now
+				changed = true
+			}
+		}
+	}
+
+	// Components which are gone (e.g., a replaced disk with a new serial number) are not tracked anymore
+	for key, component := range c.components {
+		if collected[component.Metric] && !seen[key] {
+			delete(c.components, key)
+			changed = true
+		}
+	}
+
+	for _, component := range c.components {
+		labels := prometheus.Labels{}
+		maps.Copy(labels, component.Labels)
+		labels["metric"] = prometheus.BuildFQName(Namespace, "", component.Metric)
+
+		c.changes = prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "status", "changes_total"),
+			"Number of changes of the status (or state) of the component observed by the exporter.",
+			nil, labels)
+		ch <- prometheus.MustNewConstMetric(
+			c.changes, prometheus.CounterValue, float64(component.Changes))
+
+		var lastChange float64
+		if !component.LastChange.IsZero() {
+			lastChange = float64(component.LastChange.UnixNano()) / 1e9
+		}
+		c.lastChange = prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "status", "last_change_timestamp_seconds"),
+			"Time of the last change of the status (or state) of the component observed by the exporter, 0 if no change has been observed.",
+			nil, labels)
+		ch <- prometheus.MustNewConstMetric(
+			c.lastChange, prometheus.GaugeValue, lastChange)
+	}
+
+	if changed && c.stateFile != "" {
+		if err := c.save(); err != nil {
+			return fmt.Errorf("failed to save status changes state file %q. %w", c.stateFile, err)
+		}
+	}
+
+	return nil
+}
+
+// load reads the tracked components from the state file, a missing state file is not an error
+func (c *statusChangesCollector) load() error {
+	content, err := os.ReadFile(c.stateFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	components := []*statusChange{}
+	if err := json.Unmarshal(content, &components); err != nil {
+		return fmt.Errorf("failed to parse status changes state file %q. %w", c.stateFile, err)
+	}
+
+	for _, component := range components {
+		if component.Labels == nil {
+			component.Labels = map[string]string{}
+		}
+		c.components[component.key()] = component
+	}
+	return nil
+}
+
+// save writes the tracked components to the state file, the file is replaced atomically
+// so a crash can't leave a partially written state file behind
+func (c *statusChangesCollector) save() error {
+	keys := slices.Sorted(maps.Keys(c.components))
+	components := make([]*statusChange, 0, len(keys))
+	for _, key := range keys {
+		components = append(components, c.components[key])
+	}
+
+	content, err := json.MarshalIndent(components, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.stateFile), "."+filepath.Base(c.stateFile)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.stateFile)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/galexrt/dellhw_exporter/pkg/omreport"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatusChangesCollector(t *testing.T, backend Backend, stateFile string, now time.Time) *statusChangesCollector {
+	t.Helper()
+
+	c, err := NewStatusChangesCollector(&Config{StatusBackend: backend, StatusChangesStateFile: stateFile})
+	require.NoError(t, err)
+	sc := c.(*statusChangesCollector)
+	sc.now = func() time.Time { return now }
+	return sc
+}
+
+func setPdisks(backend *FakeBackend, status string, state string) {
+	backend.Values["StorageController"] = []omreport.Value{
+		{Name: "storage_controller_status", Value: "0", Labels: map[string]string{"id": "0"}},
+	}
+	backend.Values["StoragePdisk"] = []omreport.Value{
+		{Name: "storage_pdisk_status", Value: status, Labels: map[string]string{"controller": "0", "disk": "0_1_0"}},
+		{Name: "storage_pdisk_state", Value: state, Labels: map[string]string{"controller": "0", "disk": "0_1_0"}},
+		// Not a status metric, so it isn't tracked
+		{Name: "storage_pdisk_failure_predicted", Value: "0", Labels: map[string]string{"controller": "0", "disk": "0_1_0"}},
+	}
+}
+
+func TestStatusChangesCollector(t *testing.T) {
+	backend := NewFakeBackend()
+	setPdisks(backend, "0", "2")
+
+	c := newStatusChangesCollector(t, backend, "", time.Unix(1000, 0))
+	assert.Implements(t, (*Derived)(nil), c)
+
+	expected := `# HELP dell_hw_status_changes_total Number of changes of the status (or state) of the component observed by the exporter.
+# TYPE dell_hw_status_changes_total counter
+dell_hw_status_changes_total{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_state"} 0
+dell_hw_status_changes_total{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_status"} 0
+dell_hw_status_changes_total{id="0",metric="dell_hw_storage_controller_status"} 0
+# HELP dell_hw_status_last_change_timestamp_seconds Time of the last change of the status (or state) of the component observed by the exporter, 0 if no change has been observed.
+# TYPE dell_hw_status_last_change_timestamp_seconds gauge
+dell_hw_status_last_change_timestamp_seconds{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_state"} 0
+dell_hw_status_last_change_timestamp_seconds{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_status"} 0
+dell_hw_status_last_change_timestamp_seconds{id="0",metric="dell_hw_storage_controller_status"} 0
+`
+	reg := gatherCollector(t, c)
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected)))
+
+	// The disk fails and comes back online
+	setPdisks(backend, "3", "4")
+	c.now = func() time.Time { return time.Unix(2000, 0) }
+	_, err := reg.Gather()
+	require.NoError(t, err)
+	setPdisks(backend, "0", "2")
+	c.now = func() time.Time { return time.Unix(3000, 0) }
+
+	expected = `# HELP dell_hw_status_changes_total Number of changes of the status (or state) of the component observed by the exporter.
+# TYPE dell_hw_status_changes_total counter
+dell_hw_status_changes_total{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_state"} 2
+dell_hw_status_changes_total{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_status"} 2
+dell_hw_status_changes_total{id="0",metric="dell_hw_storage_controller_status"} 0
+# HELP dell_hw_status_last_change_timestamp_seconds Time of the last change of the status (or state) of the component observed by the exporter, 0 if no change has been observed.
+# TYPE dell_hw_status_last_change_timestamp_seconds gauge
+dell_hw_status_last_change_timestamp_seconds{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_state"} 3000
+dell_hw_status_last_change_timestamp_seconds{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_status"} 3000
+dell_hw_status_last_change_timestamp_seconds{id="0",metric="dell_hw_storage_controller_status"} 0
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected)))
+
+	// Components of failed sources are kept
+	backend.Errors["StoragePdisk"] = errors.New("failed")
+	count, err := testutil.GatherAndCount(reg, "dell_hw_status_changes_total")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	// Components which are gone are removed
+	delete(backend.Errors, "StoragePdisk")
+	backend.Values["StorageController"] = nil
+	count, err = testutil.GatherAndCount(reg, "dell_hw_status_changes_total")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestStatusChangesCollectorStateFile(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "status_changes.json")
+	backend := NewFakeBackend()
+	setPdisks(backend, "0", "2")
+
+	c := newStatusChangesCollector(t, backend, stateFile, time.Unix(1000, 0))
+	reg := gatherCollector(t, c)
+	_, err := reg.Gather()
+	require.NoError(t, err)
+
+	setPdisks(backend, "2", "2")
+	c.now = func() time.Time { return time.Unix(2000, 0) }
+	_, err = reg.Gather()
+	require.NoError(t, err)
+
+	// The changes are restored after a restart and a change while the exporter wasn't running is detected
+	setPdisks(backend, "3", "2")
+	c = newStatusChangesCollector(t, backend, stateFile, time.Unix(3000, 0))
+
+	expected := `# HELP dell_hw_status_changes_total Number of changes of the status (or state) of the component observed by the exporter.
+# TYPE dell_hw_status_changes_total counter
+dell_hw_status_changes_total{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_state"} 0
+dell_hw_status_changes_total{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_status"} 2
+dell_hw_status_changes_total{id="0",metric="dell_hw_storage_controller_status"} 0
+# HELP dell_hw_status_last_change_timestamp_seconds Time of the last change of the status (or state) of the component observed by the exporter, 0 if no change has been observed.
+# TYPE dell_hw_status_last_change_timestamp_seconds gauge
+dell_hw_status_last_change_timestamp_seconds{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_state"} 0
+dell_hw_status_last_change_timestamp_seconds{controller="0",disk="0_1_0",metric="dell_hw_storage_pdisk_status"} 3000
+dell_hw_status_last_change_timestamp_seconds{id="0",metric="dell_hw_storage_controller_status"} 0
+`
+	assert.NoError(t, testutil.GatherAndCompare(gatherCollector(t, c), strings.NewReader(expected)))
+
+	// An invalid state file is an error
+	require.NoError(t, os.WriteFile(stateFile, []byte("{"), 0o644))
+	_, err = NewStatusChangesCollector(&Config{StatusBackend: backend, StatusChangesStateFile: stateFile})
+	assert.Error(t, err)
+}
@@ -23,6 +23,7 @@ import (
 )
 
 type storageBatteryCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewStorageBatteryCollector returns a new storageBatteryCollector
 func NewStorageBatteryCollector(cfg *Config) (Collector, error) {
-	return &storageBatteryCollector{}, nil
+	return &storageBatteryCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *storageBatteryCollector) Update(ch chan<- prometheus.Metric) error {
-	storageBattery, err := or.StorageBattery()
+	storageBattery, err := c.backend.StorageBattery()
 	if err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ import (
 )
 
 type storageControllerCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewStorageControllerCollector returns a new storageControllerCollector
 func NewStorageControllerCollector(cfg *Config) (Collector, error) {
-	return &storageControllerCollector{}, nil
+	return &storageControllerCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *storageControllerCollector) Update(ch chan<- prometheus.Metric) error {
-	storageController, err := or.StorageController()
+	storageController, err := c.backend.StorageController()
 	if err != nil {
 		return err
 	}
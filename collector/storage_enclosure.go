@@ -23,6 +23,7 @@ import (
 )
 
 type storageEnclosureCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewStorageEnclosureCollector returns a new storageEnclosureCollector
 func NewStorageEnclosureCollector(cfg *Config) (Collector, error) {
-	return &storageEnclosureCollector{}, nil
+	return &storageEnclosureCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *storageEnclosureCollector) Update(ch chan<- prometheus.Metric) error {
-	storageEnclosure, err := or.StorageEnclosure()
+	storageEnclosure, err := c.backend.StorageEnclosure()
 	if err != nil {
 		return err
 	}
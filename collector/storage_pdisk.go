@@ -17,12 +17,14 @@ limitations under the License.
 package collector
 
 import (
+	"slices"
 	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type storagePdiskCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,20 +34,22 @@ func init() {
 
 // NewStoragePdiskCollector returns a new storagePdiskCollector
 func NewStoragePdiskCollector(cfg *Config) (Collector, error) {
-	return &storagePdiskCollector{}, nil
+	return &storagePdiskCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *storagePdiskCollector) Update(ch chan<- prometheus.Metric) error {
-	controllers, err := or.StorageController()
+	controllers, err := storageControllerIDs(c.backend)
 	if err != nil {
 		return err
 	}
-	for cid := range controllers {
+	for _, cid := range controllers {
 		logger := logger.With("controller", cid)
 		logger.Debug("collecting pdisks from controller")
 
-		storagePdisk, err := or.StoragePdisk(strconv.Itoa(cid))
+		storagePdisk, err := c.backend.StoragePdisk(cid)
 		if err != nil {
 			return err
 		}
@@ -61,10 +65,35 @@ func (c *storagePdiskCollector) Update(ch chan<- prometheus.Metric) error {
 				prometheus.BuildFQName(Namespace, "", value.Name),
 				"Overall status of physical disks + failure prediction (if available).",
 				nil, value.Labels)
-			ch <- prometheus.MustNewConstMetric(
+			metric := prometheus.MustNewConstMetric(
 				c.current, prometheus.GaugeValue, float)
+			// The serial number, model, etc. of the disks are only for the inventory
+			if value.Name == "storage_pdisk_info" {
+				metric = inventoryMetric{metric}
+			}
+			ch <- metric
 		}
 	}
 
 	return nil
 }
+
+// storageControllerIDs returns the distinct IDs of the storage controllers of the backend, there can be
+// more than one value per controller (e.g., one per state with state sets)
+func storageControllerIDs(b Backend) ([]string, error) {
+	controllers, err := b.StorageController()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, value := range controllers {
+		if value.Name != "storage_controller_status" {
+			continue
+		}
+		if id := value.Labels["id"]; !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
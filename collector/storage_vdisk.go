@@ -23,6 +23,7 @@ import (
 )
 
 type storageVdiskCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewStorageVdiskCollector returns a new storageVdiskCollector
 func NewStorageVdiskCollector(cfg *Config) (Collector, error) {
-	return &storageVdiskCollector{}, nil
+	return &storageVdiskCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *storageVdiskCollector) Update(ch chan<- prometheus.Metric) error {
-	storageVdisk, err := or.StorageVdisk()
+	storageVdisk, err := c.backend.StorageVdisk()
 	if err != nil {
 		return err
 	}
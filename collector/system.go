@@ -23,6 +23,7 @@ import (
 )
 
 type systemCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewSystemCollector returns a new systemCollector
 func NewSystemCollector(cfg *Config) (Collector, error) {
-	return &systemCollector{}, nil
+	return &systemCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *systemCollector) Update(ch chan<- prometheus.Metric) error {
-	system, err := or.System()
+	system, err := c.backend.System()
 	if err != nil {
 		return err
 	}
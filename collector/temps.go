@@ -23,6 +23,7 @@ import (
 )
 
 type tempsCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewTempsCollector returns a new tempsCollector
 func NewTempsCollector(cfg *Config) (Collector, error) {
-	return &tempsCollector{}, nil
+	return &tempsCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *tempsCollector) Update(ch chan<- prometheus.Metric) error {
-	temps, err := or.Temps()
+	temps, err := c.backend.Temps()
 	if err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ import (
 )
 
 type voltsCollector struct {
+	backend Backend
 	current *prometheus.Desc
 }
 
@@ -32,12 +33,14 @@ func init() {
 
 // NewVoltsCollector returns a new voltsCollector
 func NewVoltsCollector(cfg *Config) (Collector, error) {
-	return &voltsCollector{}, nil
+	return &voltsCollector{
+		backend: cfg.Backend,
+	}, nil
 }
 
 // Update Prometheus metrics
 func (c *voltsCollector) Update(ch chan<- prometheus.Metric) error {
-	volts, err := or.Volts()
+	volts, err := c.backend.Volts()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package omreport
+
+import (
+	"fmt"
+	"sync"
+)
+
+// cycle coalesces the omreport commands of the currently active collection cycle(s)
+var cycle = &coalescer{}
+
+// coalescer makes sure each distinct command is only run once while a collection cycle is active,
+// concurrent and later callers of the same command get the output of the first run.
+type coalescer struct {
+	mu     sync.Mutex
+	active int
+	calls  map[string]*coalescedCall
+}
+
+type coalescedCall struct {
+	wg  sync.WaitGroup
+	out string
+	err error
+}
+
+// StartCycle starts a collection cycle, until the returned func is called each distinct omreport
+// command is run at most once and its output (or error) is shared between all callers.
+// Overlapping cycles share the command outputs, they are dropped when the last cycle has ended.
+func StartCycle() func() {
+	return cycle.start()
+}
+
+func (c *coalescer) start() func() {
+	c.mu.Lock()
+	c.active++
+	if c.calls == nil {
+		c.calls = map[string]*coalescedCall{}
+	}
+	c.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.active--
+			if c.active == 0 {
+				c.calls = nil
+			}
+		})
+	}
+}
+
+func (c *coalescer) do(key string, fn func() (string, error)) (string, error) {
+	c.mu.Lock()
+	if c.active == 0 {
+		c.mu.Unlock()
+		return fn()
+	}
+
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		logger.Debug("reusing command output of current collection cycle", "command", key)
+		call.wg.Wait()
+		return call.out, call.err
+	}
+
+	call := &coalescedCall{}
+	call.wg.Add(1)
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	// If fn panics the waiting callers get an error and the command is run again by the next caller
+	completed := false
+	defer func() {
+		if !completed {
+			call.err = fmt.Errorf("command %q panicked", key)
+			c.mu.Lock()
+			if c.calls[key] == call {
+				delete(c.calls, key)
+			}
+			c.mu.Unlock()
+		}
+		call.wg.Done()
+	}()
+
+	call.out, call.err = fn()
+	completed = true
+
+	return call.out, call.err
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package omreport
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalescer(t *testing.T) {
+	c := &coalescer{}
+
+	var runs atomic.Int32
+	fn := func() (string, error) {
+		runs.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return "output", nil
+	}
+
+	// Without an active cycle every call runs the command
+	c.do("chassis", fn)
+	c.do("chassis", fn)
+	assert.Equal(t, int32(2), runs.Load())
+
+	runs.Store(0)
+	end := c.start()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			out, err := c.do("chassis", fn)
+			assert.NoError(t, err)
+			assert.Equal(t, "output", out)
+		})
+	}
+	wg.Wait()
+	c.do("storage controller", fn)
+	assert.Equal(t, int32(2), runs.Load())
+
+	// Errors are shared as well
+	_, err := c.do("storage pdisk", func() (string, error) {
+		return "", errors.New("failed")
+	})
+	assert.Error(t, err)
+	_, err = c.do("storage pdisk", fn)
+	assert.Error(t, err)
+
+	end()
+	end()
+	assert.Equal(t, 0, c.active)
+
+	c.do("chassis", fn)
+	assert.Equal(t, int32(3), runs.Load())
+}
+
+func TestCoalescerPanic(t *testing.T) {
+	c := &coalescer{}
+	end := c.start()
+	defer end()
+
+	started := make(chan struct{})
+	waiterErr := make(chan error, 1)
+	go func() {
+		<-started
+		_, err := c.do("chassis", func() (string, error) { return "output", nil })
+		waiterErr <- err
+	}()
+
+	assert.Panics(t, func() {
+		c.do("chassis", func() (string, error) {
+			close(started)
+			// Give the waiter time to wait for this call
+			time.Sleep(50 * time.Millisecond)
+			panic("parser bug")
+		})
+	})
+
+	// The waiter isn't blocked forever, it gets an error (or runs the command if it came too late)
+	select {
+	case err := <-waiterErr:
+		if err != nil {
+			assert.ErrorContains(t, err, "panicked")
+		}
+	case <-time.After(5 * time.Second):
+		assert.FailNow(t, "waiter blocked after the panic")
+	}
+
+	// The command is run again by the next caller
+	out, err := c.do("chassis", func() (string, error) { return "output", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "output", out)
+}
+
+func TestStartCycleRunsCommandOnce(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires a shell")
+	}
+
+	dir := t.TempDir()
+	countFile := filepath.Join(dir, "count")
+	executable := filepath.Join(dir, "omreport")
+	script := `#!/bin/sh
+echo "$@" >> ` + countFile + `
+printf 'Health\n\nMain System Chassis\n\nSEVERITY;COMPONENT\nOk;Fans\n'
+`
+	assert.NoError(t, os.WriteFile(executable, []byte(script), 0o755))
+
+	or := New(&Options{OMReportExecutable: executable})
+
+	end := StartCycle()
+	for range 3 {
+		values, err := or.Chassis()
+		assert.NoError(t, err)
+		assert.Len(t, values, 1)
+	}
+	end()
+
+	_, err := or.Chassis()
+	assert.NoError(t, err)
+
+	out, err := os.ReadFile(countFile)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(out), "chassis -fmt ssv"))
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package omreport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FixtureFileName returns the file name of the fixture (captured `-fmt ssv` output) for the omreport
+// command args, e.g., "storage_pdisk_controller=0.txt" for "storage pdisk controller=0"
+func FixtureFileName(args ...string) string {
+	return strings.Join(args, "_") + ".txt"
+}
+
+// NewFixtureReader returns a Reader which reads the omreport outputs from the fixture files in dir
+// instead of running omreport, see FixtureFileName for the naming of the files.
+func NewFixtureReader(dir string) func(f func(Output), mode ReaderMode, cmd string, args ...string) error {
+	return func(f func(Output), mode ReaderMode, _ string, args ...string) error {
+		file := filepath.Join(dir, FixtureFileName(args...))
+		input, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read fixture for command (\"%s\"). %w", strings.Join(args, " "), err)
+		}
+
+		f(parseOutput(mode, string(input)))
+
+		return nil
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package omreport
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// allTests are the inputs of all OMReport method tests, used as the seed corpus of the fuzz tests
+var allTests = [][]testResultOMReport{
+	chassisTests,
+	chassisInfoTests,
+	fansTests,
+	memoryTests,
+	systemTests,
+	storageBatteryTests,
+	storageControllerTests,
+	storageEnclosureTests,
+	storagePdiskTests,
+	storageVdiskTests,
+	nicTests,
+	psTests,
+	psAmpsSysboardPwrTests,
+	processorsTests,
+	tempsTests,
+	voltsTests,
+	chassisBatteriesTests,
+	chassisBiosTests,
+	chassisFirmwareTests,
+	chassisIntrusionTests,
+	chassisRemovableFlashMediaTests,
+	chassisFrontPanelTests,
+	chassisSlotsTests,
+	chassisHWPerformanceTests,
+	chassisPwrManagementTests,
+}
+
+func FuzzParseOutput(f *testing.F) {
+	for _, tests := range allTests {
+		for _, test := range tests {
+			for _, mode := range []ReaderMode{DynamicReaderMode, KeyValueReaderMode, TableReaderMode} {
+				f.Add(uint8(mode), test.Input)
+			}
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, mode uint8, input string) {
+		output := parseOutput(ReaderMode(mode%3), input)
+
+		for _, report := range output {
+			if strings.Contains(report.Title, ";") || strings.Contains(report.Description, ";") {
+				t.Errorf("report title %q or description %q contains a field separator", report.Title, report.Description)
+			}
+
+			for _, line := range report.Lines {
+				if len(line) == 0 {
+					t.Errorf("report %q contains an empty line", report.Title)
+				}
+
+				for k, v := range line {
+					if k != normalizeName(k) {
+						t.Errorf("line key %q is not normalized", k)
+					}
+					if strings.Contains(v, ";") {
+						t.Errorf("line value %q contains a field separator", v)
+					}
+				}
+			}
+		}
+	})
+}
+
+// fuzzMethod fuzzes the given OMReport method with the inputs of its tests as seeds and checks
+// that every returned value is a valid metric value
+func fuzzMethod(f *testing.F, tests []testResultOMReport, method func(or *OMReport) ([]Value, error)) {
+	for _, test := range tests {
+		f.Add(test.Input)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		values, err := method(getOMReport(&input))
+		if err != nil {
+			t.Fatalf("unexpected error. %v", err)
+		}
+
+		for _, value := range values {
+			if value.Name == "" {
+				t.Errorf("value %s has no name", value)
+			}
+			if _, err := strconv.ParseFloat(value.Value, 64); err != nil {
+				t.Errorf("value %s is not a float. %v", value, err)
+			}
+		}
+	})
+}
+
+func FuzzChassis(f *testing.F) {
+	fuzzMethod(f, chassisTests, (*OMReport).Chassis)
+}
+
+func FuzzChassisInfo(f *testing.F) {
+	fuzzMethod(f, chassisInfoTests, (*OMReport).ChassisInfo)
+}
+
+func FuzzFans(f *testing.F) {
+	fuzzMethod(f, fansTests, (*OMReport).Fans)
+}
+
+func FuzzMemory(f *testing.F) {
+	fuzzMethod(f, memoryTests, (*OMReport).Memory)
+}
+
+func FuzzSystem(f *testing.F) {
+	fuzzMethod(f, systemTests, (*OMReport).System)
+}
+
+func FuzzStorageBattery(f *testing.F) {
+	fuzzMethod(f, storageBatteryTests, (*OMReport).StorageBattery)
+}
+
+func FuzzStorageController(f *testing.F) {
+	fuzzMethod(f, storageControllerTests, (*OMReport).StorageController)
+}
+
+func FuzzStorageEnclosure(f *testing.F) {
+	fuzzMethod(f, storageEnclosureTests, (*OMReport).StorageEnclosure)
+}
+
+func FuzzStoragePdisk(f *testing.F) {
+	fuzzMethod(f, storagePdiskTests, func(or *OMReport) ([]Value, error) {
+		return or.StoragePdisk("0")
+	})
+}
+
+func FuzzStorageVdisk(f *testing.F) {
+	fuzzMethod(f, storageVdiskTests, (*OMReport).StorageVdisk)
+}
+
+func FuzzNics(f *testing.F) {
+	fuzzMethod(f, nicTests, func(or *OMReport) ([]Value, error) {
+		return or.Nics()
+	})
+}
+
+func FuzzPs(f *testing.F) {
+	fuzzMethod(f, psTests, (*OMReport).Ps)
+}
+
+func FuzzPsAmpsSysboardPwr(f *testing.F) {
+	fuzzMethod(f, psAmpsSysboardPwrTests, (*OMReport).PsAmpsSysboardPwr)
+}
+
+func FuzzProcessors(f *testing.F) {
+	fuzzMethod(f, processorsTests, (*OMReport).Processors)
+}
+
+func FuzzTemps(f *testing.F) {
+	fuzzMethod(f, tempsTests, (*OMReport).Temps)
+}
+
+func FuzzVolts(f *testing.F) {
+	fuzzMethod(f, voltsTests, (*OMReport).Volts)
+}
+
+func FuzzChassisBatteries(f *testing.F) {
+	fuzzMethod(f, chassisBatteriesTests, (*OMReport).ChassisBatteries)
+}
+
+func FuzzChassisBios(f *testing.F) {
+	fuzzMethod(f, chassisBiosTests, (*OMReport).ChassisBios)
+}
+
+func FuzzChassisFirmware(f *testing.F) {
+	fuzzMethod(f, chassisFirmwareTests, (*OMReport).ChassisFirmware)
+}
+
+func FuzzChassisIntrusion(f *testing.F) {
+	fuzzMethod(f, chassisIntrusionTests, (*OMReport).ChassisIntrusion)
+}
+
+func FuzzChassisRemovableFlashMedia(f *testing.F) {
+	fuzzMethod(f, chassisRemovableFlashMediaTests, (*OMReport).ChassisRemovableFlashMedia)
+}
+
+func FuzzChassisFrontPanel(f *testing.F) {
+	fuzzMethod(f, chassisFrontPanelTests, (*OMReport).ChassisFrontPanel)
+}
+
+func FuzzChassisSlots(f *testing.F) {
+	fuzzMethod(f, chassisSlotsTests, (*OMReport).ChassisSlots)
+}
+
+func FuzzChassisHWPerformance(f *testing.F) {
+	fuzzMethod(f, chassisHWPerformanceTests, (*OMReport).ChassisHWPerformance)
+}
+
+func FuzzChassisPwrManagement(f *testing.F) {
+	fuzzMethod(f, chassisPwrManagementTests, (*OMReport).ChassisPwrManagement)
+}
@@ -21,6 +21,8 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 const (
@@ -36,6 +38,30 @@ const (
 	controllerNameLabel = "controller_name"
 )
 
+// pdiskInfoFields maps the labels of the storage_pdisk_info value to the fields of the pdisk output
+var pdiskInfoFields = map[string]string{
+	"model":        "product_id",
+	"vendor":       "vendor_id",
+	"revision":     "revision",
+	"part_number":  "part_number",
+	"media":        "media",
+	"bus_protocol": "bus_protocol",
+}
+
+// infoMetrics are the metrics which carry their information in the labels (the value is always 0)
+var infoMetrics = map[string]bool{
+	"bios":               true,
+	"chassis_info":       true,
+	"firmware":           true,
+	"storage_pdisk_info": true,
+}
+
+// IsInfoMetric returns true if the metric carries its information in the labels, e.g., the serial
+// number of a disk in storage_pdisk_info
+func IsInfoMetric(name string) bool {
+	return infoMetrics[name]
+}
+
 type ReaderMode int
 
 const (
@@ -47,9 +73,19 @@ const (
 // Options allow to set options for the OMReport package
 type Options struct {
 	OMReportExecutable string
+	// CommandTimeout overrides the global command timeout (see SetCommandTimeout) if set
+	CommandTimeout time.Duration
+	// StateSets exposes enum-like values (e.g., storage_pdisk_state) as one value per possible state
+	StateSets bool
+	// SeverityModel defines how the status strings are mapped to values, defaults to SeverityModelLegacy
+	SeverityModel SeverityModel
+	// SkipAbsent leaves out the components which aren't installed (e.g., an unoccupied CPU socket),
+	// which omreport reports with an "Unknown" status
+	SkipAbsent bool
 }
 
-// OMReport contains the Options and a Reader to mock outputs during development
+// OMReport contains the Options and a Reader to mock outputs during development,
+// if no Reader is set the omreport executable is run
 type OMReport struct {
 	Options *Options
 	Reader  func(f func(Output), mode ReaderMode, cmd string, args ...string) error
@@ -84,19 +120,54 @@ func New(opts *Options) *OMReport {
 
 	return &OMReport{
 		Options: opts,
-		Reader:  readOmreport,
 	}
 }
 
-func readOmreport(f func(Output), mode ReaderMode, omreportExecutable string, args ...string) error {
+// WithCommandTimeout returns a copy of the OMReport that uses the given command timeout
+func (or *OMReport) WithCommandTimeout(timeout time.Duration) *OMReport {
+	return or.WithOptions(func(opts *Options) {
+		opts.CommandTimeout = timeout
+	})
+}
+
+// WithOptions returns a copy of the OMReport with the Options changed by fn
+func (or *OMReport) WithOptions(fn func(opts *Options)) *OMReport {
+	opts := Options{}
+	if or.Options != nil {
+		opts = *or.Options
+	}
+	fn(&opts)
+
+	return &OMReport{
+		Options: &opts,
+		Reader:  or.Reader,
+	}
+}
+
+func (or *OMReport) readOmreport(f func(Output), mode ReaderMode, omreportExecutable string, args ...string) error {
+	name := commandName(args)
 	args = append(args, "-fmt", "ssv")
-	return readCommand(func(input string) error {
-		output := parseOutput(mode, input)
 
-		f(output)
+	input, err := cycle.do(omreportExecutable+" "+strings.Join(args, " "), func() (string, error) {
+		release := acquireCommandSlot(name)
+		defer release()
+
+		var out string
+		err := readCommandTimeout(or.getCommandTimeout(), func(input string) error {
+			out = input
+			return nil
+		}, nil, omreportExecutable, args...)
+		return out, err
+	})
+	if err != nil {
+		return err
+	}
+
+	output := parseOutput(mode, input)
+
+	f(output)
 
-		return nil
-	}, omreportExecutable, args...)
+	return nil
 }
 
 func (or *OMReport) getOMReportExecutable() string {
@@ -107,30 +178,56 @@ func (or *OMReport) getOMReportExecutable() string {
 	return DefaultOMReportExecutable
 }
 
+func (or *OMReport) getSeverityModel() SeverityModel {
+	if or.Options != nil && or.Options.SeverityModel != "" {
+		return or.Options.SeverityModel
+	}
+
+	return SeverityModelLegacy
+}
+
+func (or *OMReport) skipAbsent() bool {
+	return or.Options != nil && or.Options.SkipAbsent
+}
+
+func (or *OMReport) getCommandTimeout() time.Duration {
+	if or.Options != nil && or.Options.CommandTimeout > 0 {
+		return or.Options.CommandTimeout
+	}
+
+	return time.Duration(atomic.LoadInt64(&cmdTimeout)) * time.Second
+}
+
 func (or *OMReport) readReport(f func(Output), mode ReaderMode, omreportExecutable string, args ...string) error {
-	return or.Reader(f, mode, omreportExecutable, args...)
+	if or.Reader != nil {
+		return or.Reader(f, mode, omreportExecutable, args...)
+	}
+
+	return or.readOmreport(f, mode, omreportExecutable, args...)
 }
 
 // Chassis returns the chassis status
 func (or *OMReport) Chassis() ([]Value, error) {
 	values := []Value{}
+	pe := newParseErrors("chassis")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if !hasKeys(fields, "severity", "component") {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
 				component := strings.Replace(fields["component"], " ", "_", -1)
 				values = append(values, Value{
 					Name:   "chassis_status",
-					Value:  severity(fields["severity"]),
+					Value:  or.severity(fields["severity"]),
 					Labels: map[string]string{"component": component},
 				})
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "chassis")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // ChassisInfo returns the chassis information
@@ -155,25 +252,45 @@ func (or *OMReport) ChassisInfo() ([]Value, error) {
 	return values, err
 }
 
+// ChassisServiceTag returns the service tag of the chassis
+func (or *OMReport) ChassisServiceTag() (string, error) {
+	serviceTag := ""
+	err := or.readReport(func(outputs Output) {
+		for _, output := range outputs {
+			for _, fields := range output.Lines {
+				if v, ok := fields["chassis_service_tag"]; ok && serviceTag == "" {
+					serviceTag = v
+				}
+			}
+		}
+	}, KeyValueReaderMode, or.getOMReportExecutable(), "chassis", "information")
+	if err == nil && serviceTag == "" {
+		err = fmt.Errorf("no service tag in omreport chassis information output")
+	}
+	return serviceTag, err
+}
+
 // Fans returns the fan status and if supported RPM reading
 func (or *OMReport) Fans() ([]Value, error) {
 	values := []Value{}
+	pe := newParseErrors("chassis fans")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if _, err := strconv.Atoi(fields["index"]); err != nil {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
 				ts := map[string]string{"fan": replace(fields["probe_name"])}
 				values = append(values, Value{
 					Name:   "chassis_fan_status",
-					Value:  severity(fields["status"]),
+					Value:  or.severity(fields["status"]),
 					Labels: ts,
 				})
 
 				fs := strings.Fields(fields["reading"])
-				if len(fs) == 2 && fs[1] == "RPM" {
+				if len(fs) == 2 && fs[1] == "RPM" && isNumber(fs[0]) {
 					values = append(values, Value{
 						Name:   "chassis_fan_reading",
 						Value:  fs[0],
@@ -183,63 +300,74 @@ func (or *OMReport) Fans() ([]Value, error) {
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "chassis", "fans")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // Memory returns the memory status
 func (or *OMReport) Memory() ([]Value, error) {
 	values := []Value{}
+	pe := newParseErrors("chassis memory")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if len(fields) < 5 {
 					continue
 				}
 
 				fs := strings.Fields(fields["size"])
 				if len(fs) != 2 {
+					// Empty memory slots have no size
+					if fields["size"] != "" {
+						pe.add(ParseErrorSkippedRow, output.rawLine(i))
+					}
 					continue
 				}
 
 				values = append(values, Value{
 					Name:   "chassis_memory_status",
-					Value:  severity(fields["status"]),
+					Value:  or.severity(fields["status"]),
 					Labels: map[string]string{"memory": replace(fields["connector_name"])},
 				})
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "chassis", "memory")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // System returns the system status
 func (or *OMReport) System() ([]Value, error) {
 	values := []Value{}
+	pe := newParseErrors("system")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
-				if len(fields) != 2 || fields["severity"] == "SEVERITY" {
+			for i, fields := range output.Lines {
+				if len(fields) != 2 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
+					continue
+				}
+				if fields["severity"] == "SEVERITY" {
 					continue
 				}
 				component := replace(fields["component"])
 				values = append(values, Value{
 					Name:   "system_status",
-					Value:  severity(fields["severity"]),
+					Value:  or.severity(fields["severity"]),
 					Labels: map[string]string{"component": component},
 				})
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "system")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // StorageBattery returns the storage battery ("RAID batteries")
 func (or *OMReport) StorageBattery() ([]Value, error) {
 	values := []Value{}
 	controllerName := "N/A"
+	pe := newParseErrors("storage battery")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if strings.HasPrefix(output.Title, storageControllerNamePrefix) {
 					controllerName = strings.TrimPrefix(output.Title, storageControllerNamePrefix)
 				} else if strings.HasPrefix(output.Description, storageControllerNamePrefix) {
@@ -247,12 +375,13 @@ func (or *OMReport) StorageBattery() ([]Value, error) {
 				}
 
 				if len(fields) < 3 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 				id := strings.Replace(fields["id"], ":", "_", -1)
 				values = append(values, Value{
 					Name:  "storage_battery_status",
-					Value: severity(fields["status"]),
+					Value: or.severity(fields["status"]),
 					Labels: map[string]string{
 						controllerLabel:     id,
 						controllerNameLabel: controllerName,
@@ -261,26 +390,27 @@ func (or *OMReport) StorageBattery() ([]Value, error) {
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "storage", "battery")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // StorageController returns the storage controller status
 func (or *OMReport) StorageController() ([]Value, error) {
 	values := []Value{}
 	controllerName := "N/A"
+	pe := newParseErrors("storage controller")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if len(fields) < 3 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
 				controllerName = fmt.Sprintf("%s (Slot %s)", fields["name"], fields["slot_id"])
-				or.StoragePdisk(fields["id"])
 				id := strings.Replace(fields["id"], ":", "_", -1)
 				values = append(values, Value{
 					Name:  "storage_controller_status",
-					Value: severity(fields["status"]),
+					Value: or.severity(fields["status"]),
 					Labels: map[string]string{
 						"id":                id,
 						controllerNameLabel: controllerName,
@@ -289,16 +419,17 @@ func (or *OMReport) StorageController() ([]Value, error) {
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "storage", "controller")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // StorageEnclosure returns the storage enclosure status
 func (or *OMReport) StorageEnclosure() ([]Value, error) {
 	values := []Value{}
 	controllerName := "N/A"
+	pe := newParseErrors("storage enclosure")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if strings.HasPrefix(output.Title, storageEnclosureNamePrefix) {
 					controllerName = strings.TrimPrefix(output.Title, storageEnclosureNamePrefix)
 				} else if strings.HasPrefix(output.Description, storageEnclosureNamePrefix) {
@@ -306,13 +437,14 @@ func (or *OMReport) StorageEnclosure() ([]Value, error) {
 				}
 
 				if len(fields) < 3 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
 				id := strings.Replace(fields["id"], ":", "_", -1)
 				values = append(values, Value{
 					Name:  "storage_enclosure_status",
-					Value: severity(fields["status"]),
+					Value: or.severity(fields["status"]),
 					Labels: map[string]string{
 						"enclosure":         id,
 						controllerNameLabel: controllerName,
@@ -321,16 +453,17 @@ func (or *OMReport) StorageEnclosure() ([]Value, error) {
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "storage", "enclosure")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // StoragePdisk is called from the controller func, since it needs the encapsulating IDs.
 func (or *OMReport) StoragePdisk(cid string) ([]Value, error) {
 	values := []Value{}
 	controllerName := "N/A"
+	pe := newParseErrors("storage pdisk")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if strings.HasPrefix(output.Title, storageControllerNamePrefix) {
 					controllerName = strings.TrimPrefix(output.Title, storageControllerNamePrefix)
 				} else if strings.HasPrefix(output.Description, storageControllerNamePrefix) {
@@ -338,6 +471,7 @@ func (or *OMReport) StoragePdisk(cid string) ([]Value, error) {
 				}
 
 				if len(fields) < 3 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 				// Need to find out what the various ID formats might be
@@ -345,7 +479,7 @@ func (or *OMReport) StoragePdisk(cid string) ([]Value, error) {
 
 				values = append(values, Value{
 					Name:  "storage_pdisk_status",
-					Value: severity(fields["status"]),
+					Value: or.severity(fields["status"]),
 					Labels: map[string]string{
 						controllerLabel:     cid,
 						"disk":              id,
@@ -355,14 +489,44 @@ func (or *OMReport) StoragePdisk(cid string) ([]Value, error) {
 
 				values = append(values, Value{
 					Name:  "storage_pdisk_state",
-					Value: pdiskState(fields["state"]),
+					Value: pe.state(pdiskState, fields["state"], output.rawLine(i)),
+					Labels: map[string]string{
+						controllerLabel:     cid,
+						"disk":              id,
+						controllerNameLabel: controllerName,
+					},
+				})
+
+				values = append(values, Value{
+					Name:  "storage_pdisk_state_info",
+					Value: "0",
 					Labels: map[string]string{
 						controllerLabel:     cid,
 						"disk":              id,
 						controllerNameLabel: controllerName,
+						"state":             fields["state"],
 					},
 				})
 
+				if hasKeys(fields, "Serial No.") {
+					labels := map[string]string{
+						controllerLabel:     cid,
+						"disk":              id,
+						controllerNameLabel: controllerName,
+						"serial_number":     fields["serial_no."],
+					}
+					for label, field := range pdiskInfoFields {
+						if value, ok := fields[field]; ok {
+							labels[label] = value
+						}
+					}
+					values = append(values, Value{
+						Name:   "storage_pdisk_info",
+						Value:  "0",
+						Labels: labels,
+					})
+				}
+
 				if hasKeys(fields, "Failure Predicted", "Remaining Rated Write Endurance") {
 					values = append(values, Value{
 						Name:  "storage_pdisk_failure_predicted",
@@ -399,16 +563,17 @@ func (or *OMReport) StoragePdisk(cid string) ([]Value, error) {
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "storage", "pdisk", "controller="+cid)
-	return values, err
+	return or.stateSets(values), err
 }
 
 // StorageVdisk returns the storage vdisk status
 func (or *OMReport) StorageVdisk() ([]Value, error) {
 	values := []Value{}
 	controllerName := "N/A"
+	pe := newParseErrors("storage vdisk")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if strings.HasPrefix(output.Title, storageControllerNamePrefix) {
 					controllerName = strings.TrimPrefix(output.Title, storageControllerNamePrefix)
 				} else if strings.HasPrefix(output.Description, storageControllerNamePrefix) {
@@ -416,13 +581,14 @@ func (or *OMReport) StorageVdisk() ([]Value, error) {
 				}
 
 				if len(fields) < 3 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
 				id := strings.Replace(fields["id"], ":", "_", -1)
 				values = append(values, Value{
 					Name:  "storage_vdisk_status",
-					Value: severity(fields["status"]),
+					Value: or.severity(fields["status"]),
 					Labels: map[string]string{
 						"vdisk":             id,
 						"vdisk_name":        fields["name"],
@@ -432,7 +598,7 @@ func (or *OMReport) StorageVdisk() ([]Value, error) {
 
 				values = append(values, Value{
 					Name:  "storage_vdisk_state",
-					Value: vdiskState(fields["state"]),
+					Value: pe.state(vdiskState, fields["state"], output.rawLine(i)),
 					Labels: map[string]string{
 						"vdisk":             id,
 						"vdisk_name":        fields["name"],
@@ -440,6 +606,17 @@ func (or *OMReport) StorageVdisk() ([]Value, error) {
 					},
 				})
 
+				values = append(values, Value{
+					Name:  "storage_vdisk_state_info",
+					Value: "0",
+					Labels: map[string]string{
+						"vdisk":             id,
+						"vdisk_name":        fields["name"],
+						controllerNameLabel: controllerName,
+						"state":             fields["state"],
+					},
+				})
+
 				values = append(values, Value{
 					Name:  "storage_vdisk_raidlevel",
 					Value: getNumberFromString(fields["layout"]),
@@ -453,7 +630,7 @@ func (or *OMReport) StorageVdisk() ([]Value, error) {
 				if hasKeys(fields, "read_policy") {
 					values = append(values, Value{
 						Name:  "storage_vdisk_read_policy",
-						Value: vdiskReadPolicy(fields["read_policy"]),
+						Value: pe.state(vdiskReadPolicy, fields["read_policy"], output.rawLine(i)),
 						Labels: map[string]string{
 							"vdisk":             id,
 							"vdisk_name":        fields["name"],
@@ -465,7 +642,7 @@ func (or *OMReport) StorageVdisk() ([]Value, error) {
 				if hasKeys(fields, "write_policy") {
 					values = append(values, Value{
 						Name:  "storage_vdisk_write_policy",
-						Value: vdiskWritePolicy(fields["write_policy"]),
+						Value: pe.state(vdiskWritePolicy, fields["write_policy"], output.rawLine(i)),
 						Labels: map[string]string{
 							"vdisk":             id,
 							"vdisk_name":        fields["name"],
@@ -477,7 +654,7 @@ func (or *OMReport) StorageVdisk() ([]Value, error) {
 				if hasKeys(fields, "cache_policy") {
 					values = append(values, Value{
 						Name:  "storage_vdisk_cache_policy",
-						Value: vdiskCachePolicy(fields["cache_policy"]),
+						Value: pe.state(vdiskCachePolicy, fields["cache_policy"], output.rawLine(i)),
 						Labels: map[string]string{
 							"vdisk":             id,
 							"vdisk_name":        fields["name"],
@@ -488,7 +665,7 @@ func (or *OMReport) StorageVdisk() ([]Value, error) {
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "storage", "vdisk")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // Nics returns the connection status of the NICs
@@ -505,10 +682,12 @@ func (or *OMReport) Nics(nicList ...string) ([]Value, error) {
 		monitoredNics[nic] = true
 	}
 
+	pe := newParseErrors("chassis nics")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if len(fields) < 5 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
@@ -552,10 +731,12 @@ func (or *OMReport) Nics(nicList ...string) ([]Value, error) {
 // Ps returns the power supply state and if supported input/output wattage
 func (or *OMReport) Ps() ([]Value, error) {
 	values := []Value{}
+	pe := newParseErrors("chassis pwrsupplies")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if len(fields) < 3 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
@@ -563,7 +744,7 @@ func (or *OMReport) Ps() ([]Value, error) {
 				ts := map[string]string{"id": id}
 				values = append(values, Value{
 					Name:   "ps_status",
-					Value:  severity(fields["status"]),
+					Value:  or.severity(fields["status"]),
 					Labels: ts,
 				})
 				if len(fields) < 6 {
@@ -593,7 +774,7 @@ func (or *OMReport) Ps() ([]Value, error) {
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "chassis", "pwrsupplies")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // PsAmpsSysboardPwr returns the power supply system board amps power consumption
@@ -605,7 +786,7 @@ func (or *OMReport) PsAmpsSysboardPwr() ([]Value, error) {
 				if len(fields) == 2 && strings.Contains(fields["psu"], "Current") {
 					iFields := strings.Split(fields["psu"], "Current")
 					vFields := strings.Fields(fields["amperage"])
-					if len(iFields) < 2 && len(vFields) < 2 {
+					if len(iFields) < 2 || len(vFields) < 2 || !isNumber(vFields[0]) {
 						continue
 					}
 
@@ -619,7 +800,8 @@ func (or *OMReport) PsAmpsSysboardPwr() ([]Value, error) {
 					vFields := strings.Fields(fields["reading"])
 					warnFields := strings.Fields(fields["warning_threshold"])
 					failFields := strings.Fields(fields["failure_threshold"])
-					if len(vFields) < 2 || len(warnFields) < 2 || len(failFields) < 2 {
+					if len(vFields) < 2 || len(warnFields) < 2 || len(failFields) < 2 ||
+						!isNumber(vFields[0]) || !isNumber(warnFields[0]) || !isNumber(failFields[0]) {
 						continue
 					}
 
@@ -648,52 +830,61 @@ func (or *OMReport) PsAmpsSysboardPwr() ([]Value, error) {
 // Processors returns the processors status
 func (or *OMReport) Processors() ([]Value, error) {
 	values := []Value{}
+	pe := newParseErrors("chassis processors")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if len(fields) != 8 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
 				if _, err := strconv.Atoi(fields["index"]); err != nil {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
+					continue
+				}
+				if or.skipAbsent() && notOccupied(fields["processor_brand"]) {
 					continue
 				}
 
 				values = append(values, Value{
 					Name:   "chassis_processor_status",
-					Value:  severity(fields["status"]),
+					Value:  or.severity(fields["status"]),
 					Labels: map[string]string{"processor": replace(fields["connector_name"])},
 				})
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "chassis", "processors")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // Temps returns the temperatures for the chassis including the min and max,
 // for the max value, warning and failure thresholds are returned
 func (or *OMReport) Temps() ([]Value, error) {
 	values := []Value{}
+	pe := newParseErrors("chassis temps")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if len(fields) != 8 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
 				if _, err := strconv.Atoi(fields["index"]); err != nil {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
 				ts := map[string]string{"component": replace(fields["probe_name"])}
 				values = append(values, Value{
 					Name:   "chassis_temps",
-					Value:  severity(fields["status"]),
+					Value:  or.severity(fields["status"]),
 					Labels: ts,
 				})
 
 				fs := strings.Fields(fields["reading"])
-				if len(fs) == 2 && fs[1] == "C" {
+				if len(fs) == 2 && fs[1] == "C" && isNumber(fs[0]) {
 					values = append(values, Value{
 						Name:   "chassis_temps_reading",
 						Value:  fs[0],
@@ -702,7 +893,7 @@ func (or *OMReport) Temps() ([]Value, error) {
 				}
 
 				minWarningThreshold := strings.Fields(fields["minimum_warning_threshold"])
-				if len(minWarningThreshold) == 2 && minWarningThreshold[1] == "C" {
+				if len(minWarningThreshold) == 2 && minWarningThreshold[1] == "C" && isNumber(minWarningThreshold[0]) {
 					values = append(values, Value{
 						Name:   "chassis_temps_min_warning",
 						Value:  minWarningThreshold[0],
@@ -710,7 +901,7 @@ func (or *OMReport) Temps() ([]Value, error) {
 					})
 				}
 				maxWarningThreshold := strings.Fields(fields["maximum_warning_threshold"])
-				if len(maxWarningThreshold) == 2 && maxWarningThreshold[1] == "C" {
+				if len(maxWarningThreshold) == 2 && maxWarningThreshold[1] == "C" && isNumber(maxWarningThreshold[0]) {
 					values = append(values, Value{
 						Name:   "chassis_temps_max_warning",
 						Value:  maxWarningThreshold[0],
@@ -718,7 +909,7 @@ func (or *OMReport) Temps() ([]Value, error) {
 					})
 				}
 				minFailureThreshold := strings.Fields(fields["minimum_failure_threshold"])
-				if len(minFailureThreshold) == 2 && minFailureThreshold[1] == "C" {
+				if len(minFailureThreshold) == 2 && minFailureThreshold[1] == "C" && isNumber(minFailureThreshold[0]) {
 					values = append(values, Value{
 						Name:   "chassis_temps_min_failure",
 						Value:  minFailureThreshold[0],
@@ -726,7 +917,7 @@ func (or *OMReport) Temps() ([]Value, error) {
 					})
 				}
 				maxFailureThreshold := strings.Fields(fields["maximum_failure_threshold"])
-				if len(maxFailureThreshold) == 2 && maxFailureThreshold[1] == "C" {
+				if len(maxFailureThreshold) == 2 && maxFailureThreshold[1] == "C" && isNumber(maxFailureThreshold[0]) {
 					values = append(values, Value{
 						Name:   "chassis_temps_max_failure",
 						Value:  maxFailureThreshold[0],
@@ -736,27 +927,30 @@ func (or *OMReport) Temps() ([]Value, error) {
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "chassis", "temps")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // Volts returns the chassis volts statud and if support reading
 func (or *OMReport) Volts() ([]Value, error) {
 	values := []Value{}
+	pe := newParseErrors("chassis volts")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if len(fields) != 8 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
 				if _, err := strconv.Atoi(fields["index"]); err != nil {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
 				ts := map[string]string{"component": replace(fields["probe_name"])}
 				values = append(values, Value{
 					Name:   "chassis_volts_status",
-					Value:  severity(fields["status"]),
+					Value:  or.severity(fields["status"]),
 					Labels: ts,
 				})
 				if i, err := extract(fields["reading"], "V"); err == nil {
@@ -769,16 +963,18 @@ func (or *OMReport) Volts() ([]Value, error) {
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "chassis", "volts")
-	return values, err
+	return or.stateSets(values), err
 }
 
 // ChassisBatteries returns the chassis batteries status
 func (or *OMReport) ChassisBatteries() ([]Value, error) {
 	values := []Value{}
+	pe := newParseErrors("chassis batteries")
 	err := or.readReport(func(outputs Output) {
 		for _, output := range outputs {
-			for _, fields := range output.Lines {
+			for i, fields := range output.Lines {
 				if len(fields) < 4 {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
 					continue
 				}
 
@@ -787,12 +983,253 @@ func (or *OMReport) ChassisBatteries() ([]Value, error) {
 
 				values = append(values, Value{
 					Name:   "cmos_batteries_status",
-					Value:  severity(fields["status"]),
+					Value:  or.severity(fields["status"]),
 					Labels: ts,
 				})
 			}
 		}
 	}, DynamicReaderMode, or.getOMReportExecutable(), "chassis", "batteries")
+	return or.stateSets(values), err
+}
+
+// ChassisIntrusion returns the chassis intrusion probe status and state
+func (or *OMReport) ChassisIntrusion() ([]Value, error) {
+	values := []Value{}
+	pe := newParseErrors("chassis intrusion")
+	err := or.readReport(func(outputs Output) {
+		for _, output := range outputs {
+			for i, fields := range output.Lines {
+				if !hasKeys(fields, "status", "probe_name", "state") {
+					continue
+				}
+
+				ts := map[string]string{"probe": replace(fields["probe_name"])}
+				values = append(values, Value{
+					Name:   "chassis_intrusion_status",
+					Value:  or.severity(fields["status"]),
+					Labels: ts,
+				})
+				values = append(values, Value{
+					Name:   "chassis_intrusion_detected",
+					Value:  pe.state(intrusionDetected, fields["state"], output.rawLine(i)),
+					Labels: ts,
+				})
+			}
+		}
+	}, KeyValueReaderMode, or.getOMReportExecutable(), "chassis", "intrusion")
+	return or.stateSets(values), err
+}
+
+// ChassisRemovableFlashMedia returns the status of the internal SD module (IDSDM),
+// its redundancy and the vFlash media
+func (or *OMReport) ChassisRemovableFlashMedia() ([]Value, error) {
+	values := []Value{}
+	pe := newParseErrors("chassis removableflashmedia")
+	err := or.readReport(func(outputs Output) {
+		for _, output := range outputs {
+			prefix := "chassis_sd_card"
+			if strings.HasPrefix(output.Title, "vFlash") {
+				prefix = "chassis_vflash"
+			}
+
+			for i, fields := range output.Lines {
+				if hasKeys(fields, "health") {
+					values = append(values, Value{
+						Name:   "chassis_removable_flash_media_status",
+						Value:  or.severity(fields["health"]),
+						Labels: nil,
+					})
+					continue
+				}
+				if hasKeys(fields, "internal_dual_sd_module_redundancy") {
+					values = append(values, Value{
+						Name:   "chassis_idsdm_redundancy",
+						Value:  pe.state(redundancyStatus, fields["internal_dual_sd_module_redundancy"], output.rawLine(i)),
+						Labels: nil,
+					})
+					continue
+				}
+
+				if !hasKeys(fields, "connector_name", "state") {
+					continue
+				}
+
+				ts := map[string]string{"connector": replace(fields["connector_name"])}
+				if hasKeys(fields, "status") {
+					values = append(values, Value{
+						Name:   prefix + "_status",
+						Value:  or.severity(fields["status"]),
+						Labels: ts,
+					})
+				}
+				values = append(values, Value{
+					Name:   prefix + "_present",
+					Value:  presentToBool(fields["state"]),
+					Labels: ts,
+				})
+			}
+		}
+	}, KeyValueReaderMode, or.getOMReportExecutable(), "chassis", "removableflashmedia")
+	return or.stateSets(values), err
+}
+
+// ChassisFrontPanel returns the front panel button and LCD security access state
+func (or *OMReport) ChassisFrontPanel() ([]Value, error) {
+	values := []Value{}
+	pe := newParseErrors("chassis frontpanel")
+	err := or.readReport(func(outputs Output) {
+		for _, output := range outputs {
+			for i, fields := range output.Lines {
+				for k, v := range fields {
+					if v == "[N/A]" || v == "Not Applicable" {
+						continue
+					}
+
+					if button, ok := strings.CutSuffix(k, "_button"); ok {
+						values = append(values, Value{
+							Name:   "chassis_frontpanel_button_enabled",
+							Value:  enabledToBool(v),
+							Labels: map[string]string{"button": button},
+						})
+					} else if k == "security_access" {
+						values = append(values, Value{
+							Name:   "chassis_frontpanel_lcd_security_access",
+							Value:  pe.state(frontPanelSecurityAccess, v, output.rawLine(i)),
+							Labels: nil,
+						})
+					}
+				}
+			}
+		}
+	}, KeyValueReaderMode, or.getOMReportExecutable(), "chassis", "frontpanel")
+	return values, err
+}
+
+// ChassisSlots returns the PCIe slots inventory and the count of used and free slots
+func (or *OMReport) ChassisSlots() ([]Value, error) {
+	values := []Value{}
+	used, free := 0, 0
+	pe := newParseErrors("chassis slots")
+	err := or.readReport(func(outputs Output) {
+		for _, output := range outputs {
+			for i, fields := range output.Lines {
+				if !hasKeys(fields, "index", "slot_id", "adapter") {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
+					continue
+				}
+
+				usage := slotUsage(fields)
+				if usage == "In Use" {
+					used++
+				} else {
+					free++
+				}
+
+				values = append(values, Value{
+					Name:  "chassis_slot_info",
+					Value: "0",
+					Labels: map[string]string{
+						"slot":           replace(fields["slot_id"]),
+						"adapter":        strings.Trim(fields["adapter"], "[]"),
+						"data_bus_width": valueOrNA(fields["data_bus_width"]),
+						"slot_type":      valueOrNA(fields["slot_type"]),
+						"usage":          usage,
+					},
+				})
+			}
+		}
+	}, DynamicReaderMode, or.getOMReportExecutable(), "chassis", "slots")
+
+	if len(values) > 0 {
+		values = append(values, Value{
+			Name:   "chassis_slots_used",
+			Value:  strconv.Itoa(used),
+			Labels: nil,
+		}, Value{
+			Name:   "chassis_slots_free",
+			Value:  strconv.Itoa(free),
+			Labels: nil,
+		})
+	}
+
+	return values, err
+}
+
+// ChassisHWPerformance returns if the hardware performance is degraded and the cause
+func (or *OMReport) ChassisHWPerformance() ([]Value, error) {
+	values := []Value{}
+	pe := newParseErrors("chassis hwperformance")
+	err := or.readReport(func(outputs Output) {
+		for _, output := range outputs {
+			for i, fields := range output.Lines {
+				if !hasKeys(fields, "probe_name", "status") {
+					pe.add(ParseErrorSkippedRow, output.rawLine(i))
+					continue
+				}
+
+				values = append(values, Value{
+					Name:  "chassis_hwperformance_degraded",
+					Value: degradedToBool(fields["status"]),
+					Labels: map[string]string{
+						"probe": replace(fields["probe_name"]),
+						"cause": valueOrNA(fields["cause"]),
+					},
+				})
+			}
+		}
+	}, DynamicReaderMode, or.getOMReportExecutable(), "chassis", "hwperformance")
+	return values, err
+}
+
+// ChassisPwrManagement returns the power inventory, budget, power cap and profile
+func (or *OMReport) ChassisPwrManagement() ([]Value, error) {
+	values := []Value{}
+	err := or.readReport(func(outputs Output) {
+		for _, output := range outputs {
+			for _, fields := range output.Lines {
+				for k, v := range fields {
+					switch k {
+					case "system_idle_power":
+						if w, err := extractWatts(v); err == nil {
+							values = append(values, Value{
+								Name:   "chassis_power_idle_watts",
+								Value:  w,
+								Labels: nil,
+							})
+						}
+					case "system_maximum_potential_power":
+						if w, err := extractWatts(v); err == nil {
+							values = append(values, Value{
+								Name:   "chassis_power_budget_watts",
+								Value:  w,
+								Labels: nil,
+							})
+						}
+					case "enable_power_cap":
+						values = append(values, Value{
+							Name:   "chassis_power_cap_enabled",
+							Value:  enabledToBool(v),
+							Labels: nil,
+						})
+					case "power_cap":
+						if w, err := extractWatts(v); err == nil {
+							values = append(values, Value{
+								Name:   "chassis_power_cap_watts",
+								Value:  w,
+								Labels: nil,
+							})
+						}
+					case "active_power_profile", "power_profile":
+						values = append(values, Value{
+							Name:   "chassis_power_profile_info",
+							Value:  "0",
+							Labels: map[string]string{"profile": v},
+						})
+					}
+				}
+			}
+		}
+	}, KeyValueReaderMode, or.getOMReportExecutable(), "chassis", "pwrmanagement")
 	return values, err
 }
 
@@ -826,6 +1263,24 @@ func (or *OMReport) ChassisBios() ([]Value, error) {
 	return values, err
 }
 
+// OMSAVersion returns the version of OMSA (Dell OpenManage Server Administrator)
+func (or *OMReport) OMSAVersion() (string, error) {
+	version := ""
+	err := or.readReport(func(outputs Output) {
+		for _, output := range outputs {
+			for _, fields := range output.Lines {
+				if v, ok := fields["version"]; ok && version == "" {
+					version = v
+				}
+			}
+		}
+	}, KeyValueReaderMode, or.getOMReportExecutable(), "about")
+	if err == nil && version == "" {
+		err = fmt.Errorf("no version in omreport about output")
+	}
+	return version, err
+}
+
 // ChassisFirmware returns the firmware revisions
 func (or *OMReport) ChassisFirmware() ([]Value, error) {
 	value := Value{
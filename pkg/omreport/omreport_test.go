@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type testResultOMReport struct {
@@ -119,6 +120,34 @@ func TestChassisInfo(t *testing.T) {
 	}
 }
 
+func TestChassisServiceTag(t *testing.T) {
+	input := chassisInfoTests[0].Input
+	report := getOMReport(&input)
+	serviceTag, err := report.ChassisServiceTag()
+	assert.NoError(t, err)
+	assert.Equal(t, "123XXX", serviceTag)
+
+	input = ""
+	_, err = report.ChassisServiceTag()
+	assert.Error(t, err)
+}
+
+func TestOMSAVersion(t *testing.T) {
+	input := `Product name;Dell OpenManage Server Administrator
+Version;9.5.0
+Copyright;Copyright (C) Dell Inc. 1995-2020 All rights reserved.
+Company;Dell Inc.
+`
+	report := getOMReport(&input)
+	version, err := report.OMSAVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "9.5.0", version)
+
+	input = ""
+	_, err = report.OMSAVersion()
+	assert.Error(t, err)
+}
+
 var fansTests = []testResultOMReport{
 	{
 		Input: `Fan Probes Information
@@ -486,6 +515,32 @@ ID;Status;Name;State;Power Status;Bus Protocol;Media;Part of Cache Pool;Remainin
 					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
 				},
 			},
+			{
+				Name:  "storage_pdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"controller":        "0",
+					"disk":              "0_1_0",
+					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
+					"state":             "Ready",
+				},
+			},
+			{
+				Name:  "storage_pdisk_info",
+				Value: "0",
+				Labels: map[string]string{
+					"controller":        "0",
+					"disk":              "0_1_0",
+					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
+					"bus_protocol":      "SATA",
+					"media":             "SSD",
+					"model":             "INTEL SSDSC2BX200G4R",
+					"part_number":       "CN03481GIT2006AT00P3A0",
+					"revision":          "G201DL2B",
+					"serial_number":     "BTHC643503A2200TGN",
+					"vendor":            "DELL(tm)",
+				},
+			},
 			{
 				Name:  "storage_pdisk_failure_predicted",
 				Value: "0",
@@ -522,6 +577,32 @@ ID;Status;Name;State;Power Status;Bus Protocol;Media;Part of Cache Pool;Remainin
 					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
 				},
 			},
+			{
+				Name:  "storage_pdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"controller":        "0",
+					"disk":              "0_1_1",
+					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
+					"state":             "Online",
+				},
+			},
+			{
+				Name:  "storage_pdisk_info",
+				Value: "0",
+				Labels: map[string]string{
+					"controller":        "0",
+					"disk":              "0_1_1",
+					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
+					"bus_protocol":      "SATA",
+					"media":             "SSD",
+					"model":             "INTEL SSDSC2BX200G4R",
+					"part_number":       "CN03481GIT2006AT00PGA0",
+					"revision":          "G201DL2B",
+					"serial_number":     "BTHC643503BX200TGN",
+					"vendor":            "DELL(tm)",
+				},
+			},
 			{
 				Name:  "storage_pdisk_failure_predicted",
 				Value: "0",
@@ -558,6 +639,32 @@ ID;Status;Name;State;Power Status;Bus Protocol;Media;Part of Cache Pool;Remainin
 					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
 				},
 			},
+			{
+				Name:  "storage_pdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"controller":        "0",
+					"disk":              "0_2_0",
+					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
+					"state":             "Online",
+				},
+			},
+			{
+				Name:  "storage_pdisk_info",
+				Value: "0",
+				Labels: map[string]string{
+					"controller":        "0",
+					"disk":              "0_2_0",
+					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
+					"bus_protocol":      "SATA",
+					"media":             "SSD",
+					"model":             "INTEL SSDSC2BX200G4R",
+					"part_number":       "CN03481GIT2006AT00PGA0",
+					"revision":          "G201DL2B",
+					"serial_number":     "BTHC643503BX200TGN",
+					"vendor":            "DELL(tm)",
+				},
+			},
 			{
 				Name:  "storage_pdisk_failure_predicted",
 				Value: "1",
@@ -606,6 +713,32 @@ ID;Status;Name;State;Power Status;Bus Protocol;Media;Part of Cache Pool;Remainin
 					controllerNameLabel: "PERC H330 Mini (Embedded)",
 				},
 			},
+			{
+				Name:  "storage_pdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"controller":        "0",
+					"disk":              "0_1_0",
+					controllerNameLabel: "PERC H330 Mini (Embedded)",
+					"state":             "Non-RAID",
+				},
+			},
+			{
+				Name:  "storage_pdisk_info",
+				Value: "0",
+				Labels: map[string]string{
+					"controller":        "0",
+					"disk":              "0_1_0",
+					controllerNameLabel: "PERC H330 Mini (Embedded)",
+					"bus_protocol":      "SATA",
+					"media":             "SSD",
+					"model":             "MTFDDAK480TDN",
+					"part_number":       "SG0D35F3MCS0004416JZA02",
+					"revision":          "D1DF005",
+					"serial_number":     "2014274E8D30",
+					"vendor":            "DELL(tm)",
+				},
+			},
 			{
 				Name:  "storage_pdisk_failure_predicted",
 				Value: "0",
@@ -676,6 +809,16 @@ ID;Status;Name;State;Hot Spare Policy violated;Encrypted;Layout;Size;T10 Protect
 					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
 				},
 			},
+			{
+				Name:  "storage_vdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"vdisk":             "0",
+					"vdisk_name":        "GenericR5_0",
+					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
+					"state":             "Ready",
+				},
+			},
 			{
 				Name:  "storage_vdisk_raidlevel",
 				Value: "5",
@@ -730,6 +873,16 @@ ID;Status;Name;State;Hot Spare Policy violated;Encrypted;Layout;Size;T10 Protect
 					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
 				},
 			},
+			{
+				Name:  "storage_vdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"vdisk":             "1",
+					"vdisk_name":        "GenericR10_0",
+					controllerNameLabel: "PERC H730 Mini (Slot Embedded)",
+					"state":             "Ready",
+				},
+			},
 			{
 				Name:  "storage_vdisk_raidlevel",
 				Value: "10",
@@ -802,6 +955,16 @@ ID;Status;Name;State;Hot Spare Policy violated;Encrypted;Layout;Size;T10 Protect
 					controllerNameLabel: "PERC H730 Mini (Embedded)",
 				},
 			},
+			{
+				Name:  "storage_vdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"vdisk":             "0",
+					"vdisk_name":        "Virtual Disk0",
+					controllerNameLabel: "PERC H730 Mini (Embedded)",
+					"state":             "Ready",
+				},
+			},
 			{
 				Name:  "storage_vdisk_raidlevel",
 				Value: "1",
@@ -869,6 +1032,16 @@ ID;Status;Name;State;Encrypted;Layout;Size;T10 Protection Information Status;Ass
 					controllerNameLabel: "BOSS-S1 (Embedded)",
 				},
 			},
+			{
+				Name:  "storage_vdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"vdisk":             "0",
+					"vdisk_name":        "VD_R1_1",
+					controllerNameLabel: "BOSS-S1 (Embedded)",
+					"state":             "Ready",
+				},
+			},
 			{
 				Name:  "storage_vdisk_raidlevel",
 				Value: "1",
@@ -936,6 +1109,16 @@ ID;Status;Name;State;Hot Spare Policy violated;Encrypted;Progress;Layout;Size;T1
 					controllerNameLabel: "PERC H730P Mini (Embedded)",
 				},
 			},
+			{
+				Name:  "storage_vdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"vdisk":             "0",
+					"vdisk_name":        "Virtual Disk0",
+					controllerNameLabel: "PERC H730P Mini (Embedded)",
+					"state":             "Ready",
+				},
+			},
 			{
 				Name:  "storage_vdisk_raidlevel",
 				Value: "6",
@@ -991,6 +1174,16 @@ ID;Status;Name;State;Hot Spare Policy violated;Encrypted;Progress;Layout;Size;T1
 					controllerNameLabel: "PERC H730P Mini (Embedded)",
 				},
 			},
+			{
+				Name:  "storage_vdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"vdisk":             "1",
+					"vdisk_name":        "Virtual Disk1",
+					controllerNameLabel: "PERC H730P Mini (Embedded)",
+					"state":             "Background Initialization",
+				},
+			},
 			{
 				Name:  "storage_vdisk_raidlevel",
 				Value: "6",
@@ -1060,6 +1253,16 @@ ID;Status;Name;State;Hot Spare Policy violated;Virtual Disk Bad Blocks;Encrypted
 					controllerNameLabel: "PERC H740P Adapter (Slot 6)",
 				},
 			},
+			{
+				Name:  "storage_vdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"vdisk":             "0",
+					"vdisk_name":        "DATA",
+					controllerNameLabel: "PERC H740P Adapter (Slot 6)",
+					"state":             "Ready",
+				},
+			},
 			{
 				Name:  "storage_vdisk_raidlevel",
 				Value: "0",
@@ -1115,6 +1318,16 @@ ID;Status;Name;State;Hot Spare Policy violated;Virtual Disk Bad Blocks;Encrypted
 					controllerNameLabel: "PERC H740P Adapter (Slot 6)",
 				},
 			},
+			{
+				Name:  "storage_vdisk_state_info",
+				Value: "0",
+				Labels: map[string]string{
+					"vdisk":             "1",
+					"vdisk_name":        "STORAGE",
+					controllerNameLabel: "PERC H740P Adapter (Slot 6)",
+					"state":             "Ready",
+				},
+			},
 			{
 				Name:  "storage_vdisk_raidlevel",
 				Value: "5",
@@ -1423,6 +1636,13 @@ func TestProcessors(t *testing.T) {
 		values, _ := report.Processors()
 		assert.Equal(t, result.Values, values)
 	}
+
+	// The unoccupied socket is left out
+	input = processorsTests[0].Input
+	report.Options = &Options{SkipAbsent: true}
+	values, err := report.Processors()
+	require.NoError(t, err)
+	assert.Equal(t, processorsTests[0].Values[:1], values)
 }
 
 var tempsTests = []testResultOMReport{
@@ -1739,3 +1959,442 @@ func TestChassisFirmware(t *testing.T) {
 		assert.Equal(t, result.Values, values)
 	}
 }
+
+var chassisIntrusionTests = []testResultOMReport{
+	{
+		Input: `Intrusion Information
+
+Health;Ok
+
+Index;Status;Probe Name;State
+0;Ok;System Board Intrusion;Chassis is closed
+
+For further help, type the command followed by -?
+`,
+		Values: []Value{
+			{
+				Name:  "chassis_intrusion_status",
+				Value: "0",
+				Labels: map[string]string{
+					"probe": "System_Board_Intrusion",
+				},
+			},
+			{
+				Name:  "chassis_intrusion_detected",
+				Value: "0",
+				Labels: map[string]string{
+					"probe": "System_Board_Intrusion",
+				},
+			},
+		},
+	},
+	{
+		Input: `Intrusion Information
+
+Health;Critical
+
+Index;Status;Probe Name;State
+0;Critical;System Board Intrusion;Chassis is open
+`,
+		Values: []Value{
+			{
+				Name:  "chassis_intrusion_status",
+				Value: "1",
+				Labels: map[string]string{
+					"probe": "System_Board_Intrusion",
+				},
+			},
+			{
+				Name:  "chassis_intrusion_detected",
+				Value: "1",
+				Labels: map[string]string{
+					"probe": "System_Board_Intrusion",
+				},
+			},
+		},
+	},
+	{
+		Input: `Intrusion Information
+
+Health;Unknown
+
+Index;Status;Probe Name;State
+0;Unknown;System Board Intrusion;Unknown
+`,
+		Values: []Value{
+			{
+				Name:  "chassis_intrusion_status",
+				Value: "1",
+				Labels: map[string]string{
+					"probe": "System_Board_Intrusion",
+				},
+			},
+			{
+				Name:  "chassis_intrusion_detected",
+				Value: StateUnknown,
+				Labels: map[string]string{
+					"probe": "System_Board_Intrusion",
+				},
+			},
+		},
+	},
+}
+
+func TestChassisIntrusion(t *testing.T) {
+	input := ""
+	report := getOMReport(&input)
+	for _, result := range chassisIntrusionTests {
+		input = result.Input
+		values, _ := report.ChassisIntrusion()
+		assert.Equal(t, result.Values, values)
+	}
+}
+
+var chassisRemovableFlashMediaTests = []testResultOMReport{
+	{
+		Input: `Removable Flash Media Information
+
+Health;Critical
+
+Internal Dual SD Module Redundancy;Lost
+
+Internal SD Card Information
+
+Index;Status;Connector Name;State;Storage Size
+0;Ok;System Board SD Status 1;Present;1.84 GB
+1;Critical;System Board SD Status 2;Absent;[N/A]
+
+vFlash Media Details
+
+Connector Name;Type;State;Available Size;Storage Size
+System Board vFlash;vFlash SD Card;Present;0 MB;7.5 GB
+`,
+		Values: []Value{
+			{
+				Name:   "chassis_removable_flash_media_status",
+				Value:  "1",
+				Labels: nil,
+			},
+			{
+				Name:   "chassis_idsdm_redundancy",
+				Value:  "2",
+				Labels: nil,
+			},
+			{
+				Name:  "chassis_sd_card_status",
+				Value: "0",
+				Labels: map[string]string{
+					"connector": "System_Board_SD_Status_1",
+				},
+			},
+			{
+				Name:  "chassis_sd_card_present",
+				Value: "1",
+				Labels: map[string]string{
+					"connector": "System_Board_SD_Status_1",
+				},
+			},
+			{
+				Name:  "chassis_sd_card_status",
+				Value: "1",
+				Labels: map[string]string{
+					"connector": "System_Board_SD_Status_2",
+				},
+			},
+			{
+				Name:  "chassis_sd_card_present",
+				Value: "0",
+				Labels: map[string]string{
+					"connector": "System_Board_SD_Status_2",
+				},
+			},
+			{
+				Name:  "chassis_vflash_present",
+				Value: "1",
+				Labels: map[string]string{
+					"connector": "System_Board_vFlash",
+				},
+			},
+		},
+	},
+}
+
+func TestChassisRemovableFlashMedia(t *testing.T) {
+	input := ""
+	report := getOMReport(&input)
+	for _, result := range chassisRemovableFlashMediaTests {
+		input = result.Input
+		values, _ := report.ChassisRemovableFlashMedia()
+		assert.Equal(t, result.Values, values)
+	}
+}
+
+var chassisFrontPanelTests = []testResultOMReport{
+	{
+		Input: `Front Panel
+
+Power Button;Enabled
+NMI Button;Disabled
+Security Access;View Only
+LCD Line 1;Service Tag
+LCD Line 2;[N/A]
+`,
+		Values: []Value{
+			{
+				Name:  "chassis_frontpanel_button_enabled",
+				Value: "1",
+				Labels: map[string]string{
+					"button": "power",
+				},
+			},
+			{
+				Name:  "chassis_frontpanel_button_enabled",
+				Value: "0",
+				Labels: map[string]string{
+					"button": "nmi",
+				},
+			},
+			{
+				Name:   "chassis_frontpanel_lcd_security_access",
+				Value:  "1",
+				Labels: nil,
+			},
+		},
+	},
+}
+
+func TestChassisFrontPanel(t *testing.T) {
+	input := ""
+	report := getOMReport(&input)
+	for _, result := range chassisFrontPanelTests {
+		input = result.Input
+		values, _ := report.ChassisFrontPanel()
+		assert.Equal(t, result.Values, values)
+	}
+}
+
+var chassisSlotsTests = []testResultOMReport{
+	{
+		Input: `Slots Information
+
+Index;Slot ID;Adapter;Data Bus Width
+0;PCIe Slot 1;[Not Occupied];8x or x8
+1;PCIe Slot 2;Broadcom 57416 Dual Port 10GbE;8x or x8
+2;PCIe Slot 3;PERC H730P Adapter;16x or x16
+
+For further help, type the command followed by -?
+`,
+		Values: []Value{
+			{
+				Name:  "chassis_slot_info",
+				Value: "0",
+				Labels: map[string]string{
+					"slot":           "PCIe_Slot_1",
+					"adapter":        "Not Occupied",
+					"data_bus_width": "8x or x8",
+					"slot_type":      "N/A",
+					"usage":          "Available",
+				},
+			},
+			{
+				Name:  "chassis_slot_info",
+				Value: "0",
+				Labels: map[string]string{
+					"slot":           "PCIe_Slot_2",
+					"adapter":        "Broadcom 57416 Dual Port 10GbE",
+					"data_bus_width": "8x or x8",
+					"slot_type":      "N/A",
+					"usage":          "In Use",
+				},
+			},
+			{
+				Name:  "chassis_slot_info",
+				Value: "0",
+				Labels: map[string]string{
+					"slot":           "PCIe_Slot_3",
+					"adapter":        "PERC H730P Adapter",
+					"data_bus_width": "16x or x16",
+					"slot_type":      "N/A",
+					"usage":          "In Use",
+				},
+			},
+			{
+				Name:   "chassis_slots_used",
+				Value:  "2",
+				Labels: nil,
+			},
+			{
+				Name:   "chassis_slots_free",
+				Value:  "1",
+				Labels: nil,
+			},
+		},
+	},
+	{
+		Input: `Slots Information
+
+Index;Slot ID;Adapter;Data Bus Width;Slot Type;Slot Usage
+0;PCIe Slot 1;[Not Occupied];8x or x8;PCI Express Gen 3;Available
+1;PCIe Slot 2;NVIDIA A100;16x or x16;PCI Express Gen 4;In Use
+`,
+		Values: []Value{
+			{
+				Name:  "chassis_slot_info",
+				Value: "0",
+				Labels: map[string]string{
+					"slot":           "PCIe_Slot_1",
+					"adapter":        "Not Occupied",
+					"data_bus_width": "8x or x8",
+					"slot_type":      "PCI Express Gen 3",
+					"usage":          "Available",
+				},
+			},
+			{
+				Name:  "chassis_slot_info",
+				Value: "0",
+				Labels: map[string]string{
+					"slot":           "PCIe_Slot_2",
+					"adapter":        "NVIDIA A100",
+					"data_bus_width": "16x or x16",
+					"slot_type":      "PCI Express Gen 4",
+					"usage":          "In Use",
+				},
+			},
+			{
+				Name:   "chassis_slots_used",
+				Value:  "1",
+				Labels: nil,
+			},
+			{
+				Name:   "chassis_slots_free",
+				Value:  "1",
+				Labels: nil,
+			},
+		},
+	},
+}
+
+func TestChassisSlots(t *testing.T) {
+	input := ""
+	report := getOMReport(&input)
+	for _, result := range chassisSlotsTests {
+		input = result.Input
+		values, _ := report.ChassisSlots()
+		assert.Equal(t, result.Values, values)
+	}
+}
+
+var chassisHWPerformanceTests = []testResultOMReport{
+	{
+		Input: `Hardware Performance
+
+Index;Probe Name;Status;Cause
+0;System Board Power Optimized;Normal;[N/A]
+1;PS Redundancy;Degraded;Power Supply Redundancy Lost
+
+For further help, type the command followed by -?
+`,
+		Values: []Value{
+			{
+				Name:  "chassis_hwperformance_degraded",
+				Value: "0",
+				Labels: map[string]string{
+					"probe": "System_Board_Power_Optimized",
+					"cause": "N/A",
+				},
+			},
+			{
+				Name:  "chassis_hwperformance_degraded",
+				Value: "1",
+				Labels: map[string]string{
+					"probe": "PS_Redundancy",
+					"cause": "Power Supply Redundancy Lost",
+				},
+			},
+		},
+	},
+}
+
+func TestChassisHWPerformance(t *testing.T) {
+	input := ""
+	report := getOMReport(&input)
+	for _, result := range chassisHWPerformanceTests {
+		input = result.Input
+		values, _ := report.ChassisHWPerformance()
+		assert.Equal(t, result.Values, values)
+	}
+}
+
+var chassisPwrManagementTests = []testResultOMReport{
+	{
+		Input: `Power Inventory and Budget
+
+Power Inventory
+System Idle Power;99 W
+System Maximum Potential Power;516 W
+
+Power Budget
+Attribute;Values
+Enable Power Cap;Enabled
+Power Cap;400 W (56%)
+
+Power Profile
+Active Power Profile;Maximum Performance
+`,
+		Values: []Value{
+			{
+				Name:   "chassis_power_idle_watts",
+				Value:  "99",
+				Labels: nil,
+			},
+			{
+				Name:   "chassis_power_budget_watts",
+				Value:  "516",
+				Labels: nil,
+			},
+			{
+				Name:   "chassis_power_cap_enabled",
+				Value:  "1",
+				Labels: nil,
+			},
+			{
+				Name:   "chassis_power_cap_watts",
+				Value:  "400",
+				Labels: nil,
+			},
+			{
+				Name:  "chassis_power_profile_info",
+				Value: "0",
+				Labels: map[string]string{
+					"profile": "Maximum Performance",
+				},
+			},
+		},
+	},
+	{
+		Input: `Power Inventory and Budget
+
+Power Budget
+Attribute;Values
+Enable Power Cap;Disabled
+Power Cap;[N/A]
+`,
+		Values: []Value{
+			{
+				Name:   "chassis_power_cap_enabled",
+				Value:  "0",
+				Labels: nil,
+			},
+		},
+	},
+}
+
+func TestChassisPwrManagement(t *testing.T) {
+	input := ""
+	report := getOMReport(&input)
+	for _, result := range chassisPwrManagementTests {
+		input = result.Input
+		values, _ := report.ChassisPwrManagement()
+		assert.Equal(t, result.Values, values)
+	}
+}
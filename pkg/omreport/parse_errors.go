@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package omreport
+
+import (
+	"sync/atomic"
+)
+
+const (
+	// ParseErrorSkippedRow a row of the output didn't have the expected fields and has been skipped
+	ParseErrorSkippedRow = "skipped_row"
+	// ParseErrorUnknownState a state (or policy) string isn't known and has been reported as -1
+	ParseErrorUnknownState = "unknown_state"
+)
+
+// parseErrorObserver is called for each parse error of an omreport command output.
+var parseErrorObserver atomic.Pointer[func(command, reason string)]
+
+// SetParseErrorObserver sets a function that is called for each row of an omreport output that
+// couldn't be parsed, with the command and the reason (e.g., ParseErrorSkippedRow)
+func SetParseErrorObserver(fn func(command, reason string)) {
+	if fn == nil {
+		parseErrorObserver.Store(nil)
+		return
+	}
+	parseErrorObserver.Store(&fn)
+}
+
+// parseErrors records the parse errors of a command's output, to not flood the logs
+// only the first offending line per reason is logged
+type parseErrors struct {
+	command string
+	logged  map[string]bool
+}
+
+func newParseErrors(command string) *parseErrors {
+	return &parseErrors{
+		command: command,
+		logged:  map[string]bool{},
+	}
+}
+
+func (p *parseErrors) add(reason string, line string) {
+	if fn := parseErrorObserver.Load(); fn != nil {
+		(*fn)(p.command, reason)
+	}
+
+	if !p.logged[reason] {
+		p.logged[reason] = true
+		logger.Debug("failed to parse omreport output line", "command", p.command, "reason", reason, "line", line)
+	}
+}
+
+// state returns the mapped state and records a parse error if the state is unknown (StateUnknown)
+func (p *parseErrors) state(mapping func(string) string, s string, line string) string {
+	state := mapping(s)
+	if state == StateUnknown {
+		p.add(ParseErrorUnknownState, line)
+	}
+	return state
+}
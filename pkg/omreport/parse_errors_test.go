@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package omreport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordParseErrors records the parse errors per command and reason until the returned func is called
+func recordParseErrors() (map[string]int, func()) {
+	errs := map[string]int{}
+	SetParseErrorObserver(func(command, reason string) {
+		errs[command+" "+reason]++
+	})
+	return errs, func() {
+		SetParseErrorObserver(nil)
+	}
+}
+
+func TestNoParseErrors(t *testing.T) {
+	errs, stop := recordParseErrors()
+	defer stop()
+
+	input := ""
+	report := getOMReport(&input)
+	for _, test := range []struct {
+		tests  []testResultOMReport
+		method func(or *OMReport) ([]Value, error)
+	}{
+		{chassisTests, (*OMReport).Chassis},
+		{fansTests, (*OMReport).Fans},
+		{memoryTests, (*OMReport).Memory},
+		{systemTests, (*OMReport).System},
+		{storageBatteryTests, (*OMReport).StorageBattery},
+		{storageControllerTests, (*OMReport).StorageController},
+		{storageEnclosureTests, (*OMReport).StorageEnclosure},
+		{storagePdiskTests, func(or *OMReport) ([]Value, error) { return or.StoragePdisk("0") }},
+		{storageVdiskTests, (*OMReport).StorageVdisk},
+		{nicTests, func(or *OMReport) ([]Value, error) { return or.Nics() }},
+		{psTests, (*OMReport).Ps},
+		{processorsTests, (*OMReport).Processors},
+		{tempsTests, (*OMReport).Temps},
+		{voltsTests, (*OMReport).Volts},
+		{chassisBatteriesTests, (*OMReport).ChassisBatteries},
+		{chassisRemovableFlashMediaTests, (*OMReport).ChassisRemovableFlashMedia},
+		{chassisFrontPanelTests, (*OMReport).ChassisFrontPanel},
+		{chassisSlotsTests, (*OMReport).ChassisSlots},
+		{chassisHWPerformanceTests, (*OMReport).ChassisHWPerformance},
+	} {
+		for _, result := range test.tests {
+			input = result.Input
+			_, err := test.method(report)
+			assert.NoError(t, err)
+		}
+	}
+
+	assert.Empty(t, errs)
+}
+
+func TestParseErrors(t *testing.T) {
+	errs, stop := recordParseErrors()
+	defer stop()
+
+	input := `Temperature Probes Information
+
+Main System Chassis Temperatures: Ok
+
+Index;Status;Probe Name;Reading;Minimum Warning Threshold;Maximum Warning Threshold;Minimum Failure Threshold;Maximum Failure Threshold;Location
+0;Ok;System Board Inlet Temp;21.0 C;3.0 C;42.0 C;-7.0 C;47.0 C;Front
+`
+	values, err := getOMReport(&input).Temps()
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+	assert.Equal(t, map[string]int{"chassis temps skipped_row": 1}, errs)
+
+	clear(errs)
+	input = `List of Physical Disks on Controller PERC H730P Mini (Embedded)
+
+Controller PERC H730P Mini (Embedded)
+ID;Status;Name;State
+0:1:0;Ok;Physical Disk 0:1:0;Online
+0:1:1;Ok;Physical Disk 0:1:1;Some New State
+0:1:2;Ok;Physical Disk 0:1:2;Another New State
+`
+	values, err = getOMReport(&input).StoragePdisk("0")
+	assert.NoError(t, err)
+	assert.Len(t, values, 9)
+	assert.Equal(t, StateUnknown, values[4].Value)
+	// The raw state is still available in the info metric
+	assert.Equal(t, "Some New State", values[5].Labels["state"])
+	assert.Equal(t, map[string]int{"storage pdisk unknown_state": 2}, errs)
+}
@@ -0,0 +1,384 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package omreport
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// StateUnknown is the value of states (and policies) which aren't known to the exporter
+const StateUnknown = "-1"
+
+// SeverityModel defines how the status strings of omreport (e.g., "Ok", "Critical") are mapped to values
+type SeverityModel string
+
+const (
+	// SeverityModelLegacy maps "Ok" to 0, "Non-Critical" to 2 and everything else
+	// (including "Unknown" and empty status) to 1
+	SeverityModelLegacy SeverityModel = "legacy"
+	// SeverityModelOrdered maps the status ordered by badness, 0 "Ok", 1 "Unknown" (and
+	// everything not known), 2 "Non-Critical", 3 "Critical" and 4 "Non-Recoverable"
+	SeverityModelOrdered SeverityModel = "ordered"
+)
+
+// ParseSeverityModel returns the SeverityModel with the given name
+func ParseSeverityModel(s string) (SeverityModel, error) {
+	switch model := SeverityModel(s); model {
+	case SeverityModelLegacy, SeverityModelOrdered:
+		return model, nil
+	}
+	return "", fmt.Errorf("unknown severity model %q (must be %q or %q)", s, SeverityModelLegacy, SeverityModelOrdered)
+}
+
+// stateMapping maps the state strings of omreport to metric values
+type stateMapping struct {
+	states map[string]string
+	// names contains the state names ordered by their value
+	names []string
+	// valueNames maps the values to the state names
+	valueNames map[string]string
+}
+
+// newStateMapping returns a stateMapping for the given states, the states are matched
+// case-insensitive and ignoring spaces, "-" and "_" (e.g., "Non-RAID" matches "Non RAID")
+func newStateMapping(states map[string]string) *stateMapping {
+	m := &stateMapping{
+		states:     make(map[string]string, len(states)),
+		names:      make([]string, 0, len(states)),
+		valueNames: make(map[string]string, len(states)),
+	}
+	for state, value := range states {
+		m.states[normalizeState(state)] = value
+		m.names = append(m.names, state)
+		m.valueNames[value] = state
+	}
+	slices.SortFunc(m.names, func(a, b string) int {
+		av, _ := strconv.Atoi(states[a])
+		bv, _ := strconv.Atoi(states[b])
+		return cmp.Compare(av, bv)
+	})
+	return m
+}
+
+// value returns the value of the state s or StateUnknown if the state isn't known
+func (m *stateMapping) value(s string) string {
+	if value, ok := m.states[normalizeState(s)]; ok {
+		return value
+	}
+	return StateUnknown
+}
+
+func normalizeState(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_':
+			return -1
+		}
+		return r
+	}, strings.ToLower(s))
+}
+
+var (
+	pdiskStates = newStateMapping(map[string]string{
+		"Unknown":              "0",
+		"Ready":                "1",
+		"Online":               "2",
+		"Degraded":             "3",
+		"Failed":               "4",
+		"Offline":              "5",
+		"Rebuilding":           "6",
+		"Incompatible":         "7",
+		"Removed":              "8",
+		"Clear":                "9",
+		"SMART Alert Detected": "10",
+		"Foreign":              "11",
+		"Unsupported":          "12",
+		"Replacing":            "13",
+		"Non-RAID":             "14",
+		"Missing":              "15",
+		"Blocked":              "16",
+		"Cryptographic Erase":  "17",
+	})
+
+	vdiskStates = newStateMapping(map[string]string{
+		"Unknown":                   "0",
+		"Ready":                     "1",
+		"Degraded":                  "2",
+		"Resynching":                "3",
+		"Resynching Paused":         "4",
+		"Regenerating":              "5",
+		"Reconstructing":            "6",
+		"Failed":                    "7",
+		"Failed Redundancy":         "8",
+		"Background Initialization": "9",
+		"Formatting":                "10",
+		"Initializing":              "11",
+		"Degraded Redundancy":       "12",
+		"Permanently Degraded":      "13",
+		"Offline":                   "14",
+		"Blocked":                   "15",
+		"Cryptographic Erase":       "16",
+	})
+
+	vdiskReadPolicies = newStateMapping(map[string]string{
+		"Not Applicable":      "0",
+		"Read Ahead":          "1",
+		"No Read Ahead":       "2",
+		"Read Cache Enabled":  "3",
+		"Read Cache Disabled": "4",
+		"Adaptive Read Ahead": "5",
+	})
+
+	vdiskWritePolicies = newStateMapping(map[string]string{
+		"Not Applicable":                "0",
+		"Write Ahead":                   "1",
+		"Force Write Back":              "2",
+		"Write Back Enabled":            "3",
+		"Write Through":                 "4",
+		"Write Cache Enabled Protected": "5",
+		"Write Cache Disabled":          "6",
+		"Write Back":                    "7",
+	})
+
+	vdiskCachePolicies = newStateMapping(map[string]string{
+		"Not Applicable": "0",
+		"Cache I/O":      "1",
+		"Direct I/O":     "2",
+	})
+
+	redundancyStates = newStateMapping(map[string]string{
+		"Full":           "0",
+		"Degraded":       "1",
+		"Lost":           "2",
+		"Disabled":       "3",
+		"Not Applicable": "4",
+	})
+
+	// legacySeverities are the values returned by legacySeverity
+	legacySeverities = newStateMapping(map[string]string{
+		"Ok":           "0",
+		"Critical":     "1",
+		"Non-Critical": "2",
+	})
+
+	// orderedSeverities are ordered by badness, so that max() returns the worst status
+	orderedSeverities = newStateMapping(map[string]string{
+		"Ok":              "0",
+		"Unknown":         "1",
+		"Non-Critical":    "2",
+		"Critical":        "3",
+		"Non-Recoverable": "4",
+	})
+
+	intrusionStates = newStateMapping(map[string]string{
+		"Chassis is closed":   "0",
+		"Chassis is open":     "1",
+		"Chassis is breached": "1",
+	})
+
+	frontPanelSecurityAccesses = newStateMapping(map[string]string{
+		"Disabled":        "0",
+		"View Only":       "1",
+		"View and Modify": "2",
+	})
+)
+
+func pdiskState(s string) string {
+	return pdiskStates.value(s)
+}
+
+func vdiskState(s string) string {
+	return vdiskStates.value(s)
+}
+
+func vdiskReadPolicy(s string) string {
+	return vdiskReadPolicies.value(s)
+}
+
+func vdiskWritePolicy(s string) string {
+	return vdiskWritePolicies.value(s)
+}
+
+func vdiskCachePolicy(s string) string {
+	return vdiskCachePolicies.value(s)
+}
+
+func redundancyStatus(s string) string {
+	return redundancyStates.value(s)
+}
+
+// intrusionDetected returns "0" if the chassis is closed, "1" if it is open (or breached) and
+// StateUnknown otherwise (e.g., "Unknown" or "[N/A]")
+func intrusionDetected(s string) string {
+	return intrusionStates.value(s)
+}
+
+func frontPanelSecurityAccess(s string) string {
+	return frontPanelSecurityAccesses.value(s)
+}
+
+// severityMetrics are the metrics with a status (severity) value
+var severityMetrics = map[string]bool{
+	"chassis_status":                       true,
+	"chassis_fan_status":                   true,
+	"chassis_memory_status":                true,
+	"chassis_processor_status":             true,
+	"chassis_temps":                        true,
+	"chassis_volts_status":                 true,
+	"chassis_intrusion_status":             true,
+	"chassis_removable_flash_media_status": true,
+	"chassis_sd_card_status":               true,
+	"chassis_vflash_status":                true,
+	"cmos_batteries_status":                true,
+	"ps_status":                            true,
+	"system_status":                        true,
+	"storage_battery_status":               true,
+	"storage_controller_status":            true,
+	"storage_enclosure_status":             true,
+	"storage_pdisk_status":                 true,
+	"storage_vdisk_status":                 true,
+}
+
+// stateSetMetrics are the enum-like metrics (besides the severityMetrics) which can be exposed
+// as state sets, see OMReport.stateSets
+var stateSetMetrics = map[string]*stateMapping{
+	"storage_pdisk_state":        pdiskStates,
+	"storage_vdisk_state":        vdiskStates,
+	"storage_vdisk_read_policy":  vdiskReadPolicies,
+	"storage_vdisk_write_policy": vdiskWritePolicies,
+	"storage_vdisk_cache_policy": vdiskCachePolicies,
+}
+
+// severity maps the status s with the configured SeverityModel
+func (or *OMReport) severity(s string) string {
+	if or.getSeverityModel() == SeverityModelOrdered {
+		return orderedSeverity(s)
+	}
+	return legacySeverity(s)
+}
+
+// orderedSeverity maps the status s by badness, unknown status are mapped to "Unknown"
+func orderedSeverity(s string) string {
+	if value := orderedSeverities.value(s); value != StateUnknown {
+		return value
+	}
+	return orderedSeverities.value("Unknown")
+}
+
+// stateSetMapping returns the mapping of the states of the metric for state sets
+func (or *OMReport) stateSetMapping(name string) (*stateMapping, bool) {
+	if severityMetrics[name] {
+		if or.getSeverityModel() == SeverityModelOrdered {
+			return orderedSeverities, true
+		}
+		return legacySeverities, true
+	}
+
+	mapping, ok := stateSetMetrics[name]
+	return mapping, ok
+}
+
+// stateSets replaces the enum-like values (see severityMetrics and stateSetMetrics) with one value per possible state,
+// with the state name in the "state" label and "1" as the value for the current state ("0" otherwise).
+// The values are returned unchanged if state sets aren't enabled in the Options.
+func (or *OMReport) stateSets(values []Value) []Value {
+	if or.Options == nil || !or.Options.StateSets {
+		return values
+	}
+
+	out := make([]Value, 0, len(values))
+	for _, value := range values {
+		mapping, ok := or.stateSetMapping(value.Name)
+		if !ok {
+			out = append(out, value)
+			continue
+		}
+
+		current := mapping.valueNames[value.Value]
+		for _, name := range mapping.names {
+			labels := maps.Clone(value.Labels)
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels["state"] = name
+
+			v := "0"
+			if name == current {
+				v = "1"
+			}
+			out = append(out, Value{
+				Name:   value.Name,
+				Value:  v,
+				Labels: labels,
+			})
+		}
+	}
+
+	return out
+}
+
+// IsStatusMetric returns true if the values of the metric are a status (severity)
+func IsStatusMetric(name string) bool {
+	return severityMetrics[name]
+}
+
+// StateName returns the name of the value of a status or state metric, e.g., "Critical" for a
+// status of "3" (status are decoded with the SeverityModelOrdered), false if the metric or value isn't known
+func StateName(name string, value string) (string, bool) {
+	mapping, ok := stateSetMetrics[name]
+	if severityMetrics[name] {
+		mapping, ok = orderedSeverities, true
+	}
+	if !ok {
+		return "", false
+	}
+
+	state, ok := mapping.valueNames[value]
+	return state, ok
+}
+
+// Normalize converts a value of a status or state metric as exposed with the Options to the value
+// without state sets and with the SeverityModelOrdered, so it can be decoded with StateName. The
+// "state" label of a state set is removed and false is returned for the states which aren't the
+// current state. The labels and value of other metrics are returned unchanged.
+func (o *Options) Normalize(name string, labels map[string]string, value string) (map[string]string, string, bool) {
+	or := &OMReport{Options: o}
+	mapping, ok := or.stateSetMapping(name)
+	if !ok {
+		return labels, value, true
+	}
+
+	state := mapping.valueNames[value]
+	if o != nil && o.StateSets {
+		if value != "1" {
+			return nil, "", false
+		}
+		state = labels["state"]
+		labels = maps.Clone(labels)
+		delete(labels, "state")
+	}
+
+	if severityMetrics[name] {
+		return labels, orderedSeverity(state), true
+	}
+	return labels, mapping.value(state), true
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package omreport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateMappingNames(t *testing.T) {
+	assert.Equal(t, []string{"Ok", "Critical", "Non-Critical"}, legacySeverities.names)
+	assert.Equal(t, "Unknown", pdiskStates.names[0])
+	assert.Equal(t, "Cryptographic Erase", pdiskStates.names[len(pdiskStates.names)-1])
+	assert.Len(t, pdiskStates.names, 18)
+}
+
+func TestStateSets(t *testing.T) {
+	input := `Health
+
+Main System Chassis
+
+SEVERITY;COMPONENT
+Non-Critical;Fans
+`
+	report := getOMReport(&input)
+	report.Options = &Options{StateSets: true}
+
+	values, err := report.Chassis()
+	assert.NoError(t, err)
+	assert.Equal(t, []Value{
+		{
+			Name:   "chassis_status",
+			Value:  "0",
+			Labels: map[string]string{"component": "Fans", "state": "Ok"},
+		},
+		{
+			Name:   "chassis_status",
+			Value:  "0",
+			Labels: map[string]string{"component": "Fans", "state": "Critical"},
+		},
+		{
+			Name:   "chassis_status",
+			Value:  "1",
+			Labels: map[string]string{"component": "Fans", "state": "Non-Critical"},
+		},
+	}, values)
+
+	input = `List of Physical Disks on Controller PERC H730P Mini (Embedded)
+
+Controller PERC H730P Mini (Embedded)
+ID;Status;Name;State
+0:1:0;Ok;Physical Disk 0:1:0;Failed
+0:1:1;Ok;Physical Disk 0:1:1;Some New State
+`
+	values, err = report.StoragePdisk("0")
+	assert.NoError(t, err)
+
+	states := map[string]map[string]string{}
+	for _, value := range values {
+		if value.Name != "storage_pdisk_state" {
+			continue
+		}
+		if states[value.Labels["disk"]] == nil {
+			states[value.Labels["disk"]] = map[string]string{}
+		}
+		states[value.Labels["disk"]][value.Labels["state"]] = value.Value
+	}
+	assert.Len(t, states["0_1_0"], len(pdiskStates.names))
+	assert.Equal(t, "1", states["0_1_0"]["Failed"])
+	assert.Equal(t, "0", states["0_1_0"]["Online"])
+	// Unknown states have no state set to 1
+	for _, v := range states["0_1_1"] {
+		assert.Equal(t, "0", v)
+	}
+
+	// The info metric isn't a state set
+	for _, value := range values {
+		if value.Name == "storage_pdisk_state_info" {
+			assert.Equal(t, "0", value.Value)
+		}
+	}
+}
+
+func TestStateSetsDisabled(t *testing.T) {
+	values := []Value{{Name: "storage_pdisk_state", Value: "2"}}
+	assert.Equal(t, values, New(&Options{}).stateSets(values))
+}
+
+func TestOrderedSeverity(t *testing.T) {
+	for input, expected := range map[string]string{
+		"Ok":              "0",
+		"Unknown":         "1",
+		"Not Applicable":  "1",
+		"":                "1",
+		"Non-Critical":    "2",
+		"Critical":        "3",
+		"Non-Recoverable": "4",
+	} {
+		assert.Equal(t, expected, orderedSeverity(input), input)
+	}
+}
+
+func TestSeverityModel(t *testing.T) {
+	input := `Health
+
+Main System Chassis
+
+SEVERITY;COMPONENT
+Ok;Fans
+Non-Recoverable;Intrusion
+Unknown;Memory
+`
+	report := getOMReport(&input)
+
+	values, err := report.Chassis()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "1", "1"}, []string{values[0].Value, values[1].Value, values[2].Value})
+
+	report.Options = &Options{SeverityModel: SeverityModelOrdered}
+	values, err = report.Chassis()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "4", "1"}, []string{values[0].Value, values[1].Value, values[2].Value})
+
+	report.Options.StateSets = true
+	values, err = report.Chassis()
+	assert.NoError(t, err)
+	assert.Len(t, values, 3*len(orderedSeverities.names))
+	assert.Equal(t, Value{
+		Name:   "chassis_status",
+		Value:  "1",
+		Labels: map[string]string{"component": "Intrusion", "state": "Non-Recoverable"},
+	}, values[2*len(orderedSeverities.names)-1])
+}
+
+func TestParseSeverityModel(t *testing.T) {
+	model, err := ParseSeverityModel("ordered")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityModelOrdered, model)
+
+	_, err = ParseSeverityModel("nagios")
+	assert.Error(t, err)
+}
+
+func TestStateName(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		value string
+		state string
+		ok    bool
+	}{
+		{"chassis_status", "3", "Critical", true},
+		{"storage_pdisk_status", "1", "Unknown", true},
+		{"storage_pdisk_state", pdiskStates.value("Failed"), "Failed", true},
+		{"storage_vdisk_write_policy", vdiskWritePolicies.value("Write Back"), "Write Back", true},
+		{"chassis_status", StateUnknown, "", false},
+		{"chassis_temps_reading", "0", "", false},
+	} {
+		state, ok := StateName(test.name, test.value)
+		assert.Equal(t, test.ok, ok, test.name)
+		assert.Equal(t, test.state, state, test.name)
+	}
+
+	assert.True(t, IsStatusMetric("chassis_temps"))
+	assert.False(t, IsStatusMetric("storage_pdisk_state"))
+}
+
+func TestNormalize(t *testing.T) {
+	disk := map[string]string{"disk": "0_1_0"}
+	for _, test := range []struct {
+		opts   *Options
+		name   string
+		labels map[string]string
+		value  string
+		want   string
+		ok     bool
+	}{
+		// Legacy "Critical" is 1, ordered 3
+		{nil, "storage_pdisk_status", disk, "1", "3", true},
+		{&Options{SeverityModel: SeverityModelLegacy}, "storage_pdisk_status", disk, "2", "2", true},
+		{&Options{SeverityModel: SeverityModelOrdered}, "storage_pdisk_status", disk, "4", "4", true},
+		{nil, "storage_pdisk_state", disk, "4", "4", true},
+		{nil, "chassis_temps_reading", disk, "34", "34", true},
+		{&Options{StateSets: true}, "storage_pdisk_status", map[string]string{"disk": "0_1_0", "state": "Critical"}, "1", "3", true},
+		{&Options{StateSets: true}, "storage_pdisk_status", map[string]string{"disk": "0_1_0", "state": "Ok"}, "0", "", false},
+		{&Options{StateSets: true}, "storage_pdisk_state", map[string]string{"disk": "0_1_0", "state": "Failed"}, "1", "4", true},
+	} {
+		labels, value, ok := test.opts.Normalize(test.name, test.labels, test.value)
+		assert.Equal(t, test.ok, ok, test.name)
+		assert.Equal(t, test.want, value, test.name)
+		if ok {
+			assert.Equal(t, disk, labels, test.name)
+		}
+	}
+}
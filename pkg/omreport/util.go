@@ -27,6 +27,7 @@ import (
 	"os/exec"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -41,6 +42,16 @@ type Report struct {
 	Description string
 
 	Lines []Line
+	// Raw contains the (cleaned) output line of each entry in Lines
+	Raw []string
+}
+
+// rawLine returns the output line of the i-th entry in Lines
+func (r Report) rawLine(i int) string {
+	if i < len(r.Raw) {
+		return r.Raw[i]
+	}
+	return ""
 }
 
 type Line = map[string]string
@@ -53,6 +64,10 @@ var (
 
 	// cmdTimeout configurable timeout for commands.
 	cmdTimeout int64 = 10
+	// cmdSemaphore limits the number of concurrently running commands, nil means unlimited.
+	cmdSemaphore atomic.Pointer[chan struct{}]
+	// queueWaitObserver is called with the time a command waited for a free execution slot.
+	queueWaitObserver atomic.Pointer[func(command string, wait time.Duration)]
 
 	logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError}))
 )
@@ -70,13 +85,22 @@ func extract(s, suffix string) (string, error) {
 	if !strings.HasSuffix(s, suffix) {
 		return "0", fmt.Errorf("extract: suffix not found")
 	}
-	s = s[:len(s)-len(suffix)]
-	return strings.TrimSpace(s), nil
+	s = strings.TrimSpace(s[:len(s)-len(suffix)])
+	if !isNumber(s) {
+		return "0", fmt.Errorf("extract: no number found")
+	}
+	return s, nil
+}
+
+// isNumber returns true if s can be used as a metric value
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
 }
 
-// severity returns 1 if s is not "Ok" or "Non-Critical" (should be "Critical" then in most cases)
+// legacySeverity returns 1 if s is not "Ok" or "Non-Critical" (should be "Critical" then in most cases)
 // elif is "Non-Critical" 2 else 0.
-func severity(s string) string {
+func legacySeverity(s string) string {
 	if s != "Ok" && s != "Non-Critical" {
 		return "1"
 	}
@@ -86,83 +110,48 @@ func severity(s string) string {
 	return "0"
 }
 
-func pdiskState(s string) string {
-	states := map[string]string{
-		"Unknown":              "0",
-		"Ready":                "1",
-		"Online":               "2",
-		"Degraded":             "3",
-		"Failed":               "4",
-		"Offline":              "5",
-		"Rebuilding":           "6",
-		"Incompatible":         "7",
-		"Removed":              "8",
-		"Clear":                "9",
-		"SMART Alert Detected": "10",
-		"Foreign":              "11",
-		"Unsupported":          "12",
-		"Replacing":            "13",
-		"Non-RAID":             "14",
-	}
-
-	return states[s]
-}
-
-func vdiskState(s string) string {
-	states := map[string]string{
-		"Ready":                     "1",
-		"Degraded":                  "2",
-		"Resynching":                "3",
-		"Resynching Paused":         "4",
-		"Regenerating":              "5",
-		"Reconstructing":            "6",
-		"Failed":                    "7",
-		"Failed Redundancy":         "8",
-		"Background Initialization": "9",
-		"Formatting":                "10",
-		"Initializing":              "11",
-		"Degraded Redundancy":       "12",
-	}
-
-	return states[s]
+// extractWatts returns the number of a watts reading, e.g., "400 W (56%)" returns "400"
+func extractWatts(s string) (string, error) {
+	fs := strings.Fields(s)
+	if len(fs) < 2 || fs[1] != "W" || !isNumber(fs[0]) {
+		return "0", fmt.Errorf("extractWatts: no watts reading found")
+	}
+	return fs[0], nil
 }
 
-func vdiskReadPolicy(s string) string {
-	policies := map[string]string{
-		"Not Applicable":      "0",
-		"Read Ahead":          "1",
-		"No Read Ahead":       "2",
-		"Read Cache Enabled":  "3",
-		"Read Cache Disabled": "4",
-		"Adaptive Read Ahead": "5",
+// degradedToBool returns "1" for "Degraded" and "0" otherwise
+func degradedToBool(s string) string {
+	if s == "Degraded" {
+		return "1"
 	}
+	return "0"
+}
 
-	return policies[s]
+// notOccupied returns true if s is "[Not Occupied]", which omreport reports for empty sockets and slots
+func notOccupied(s string) bool {
+	return strings.Trim(s, "[]") == "Not Occupied"
 }
 
-func vdiskWritePolicy(s string) string {
-	policies := map[string]string{
-		"Not Applicable":                "0",
-		"Write Ahead":                   "1",
-		"Force Write Back":              "2",
-		"Write Back Enabled":            "3",
-		"Write Through":                 "4",
-		"Write Cache Enabled Protected": "5",
-		"Write Cache Disabled":          "6",
-		"Write Back":                    "7",
+// slotUsage returns the slot usage from the "Slot Usage" field if available,
+// otherwise it is derived from whether an adapter is installed in the slot
+func slotUsage(fields Line) string {
+	if usage, ok := fields["slot_usage"]; ok && usage != "" {
+		return usage
 	}
 
-	return policies[s]
+	switch strings.Trim(fields["adapter"], "[]") {
+	case "", "Not Occupied", "Empty", "N/A":
+		return "Available"
+	}
+	return "In Use"
 }
 
-func vdiskCachePolicy(s string) string {
-	policies := map[string]string{
-		"Not Applicable": "0",
-		"Cache I/O":      "1",
-		"Direct I/O":     "2",
+// valueOrNA returns "N/A" for empty and "[N/A]" values
+func valueOrNA(s string) string {
+	if s == "" || s == "[N/A]" {
+		return "N/A"
 	}
-
-	return policies[s]
+	return s
 }
 
 // yesNoToBool returns "1" for "Yes" and "0" for "No"
@@ -173,11 +162,27 @@ func yesNoToBool(s string) string {
 	return "0"
 }
 
+// enabledToBool returns "1" for "Enabled" and "0" otherwise
+func enabledToBool(s string) string {
+	if s == "Enabled" {
+		return "1"
+	}
+	return "0"
+}
+
+// presentToBool returns "1" for "Present" and "0" otherwise
+func presentToBool(s string) string {
+	if s == "Present" {
+		return "1"
+	}
+	return "0"
+}
+
 var getNumberFromStringRegex = regexp.MustCompile("[0-9]+")
 
 func getNumberFromString(s string) string {
 	result := getNumberFromStringRegex.FindString(s)
-	if result != "" {
+	if isNumber(result) {
 		return result
 	}
 	return "-1"
@@ -243,14 +248,6 @@ func Command(timeout time.Duration, stdin io.Reader, name string, args ...string
 	return b, err
 }
 
-// ReadCommand runs command name with args and calls fn for the output from
-// stdout. Command is interrupted (if supported by Go) after 10 seconds and
-// killed after 20 seconds.
-func readCommand(fn func(string) error, name string, arg ...string) error {
-	timeout := time.Duration(int(atomic.LoadInt64(&cmdTimeout)))
-	return readCommandTimeout(timeout*time.Second, fn, nil, name, arg...)
-}
-
 // ReadCommandTimeout is the same as ReadCommand with a specifiable timeout.
 // It can also take a []byte as input (useful for chaining commands).
 func readCommandTimeout(timeout time.Duration, fn func(string) error, stdin io.Reader, name string, args ...string) error {
@@ -282,6 +279,62 @@ func SetCommandTimeout(timeout int64) {
 	atomic.StoreInt64(&cmdTimeout, timeout)
 }
 
+// SetMaxConcurrency this function can be used to limit the number of concurrently running commands,
+// zero or less means unlimited. It should be called before any command is run.
+func SetMaxConcurrency(max int) {
+	if max <= 0 {
+		cmdSemaphore.Store(nil)
+		return
+	}
+
+	sem := make(chan struct{}, max)
+	cmdSemaphore.Store(&sem)
+}
+
+// SetQueueWaitObserver sets a function that is called with the time each command
+// had to wait for a free execution slot (see SetMaxConcurrency)
+func SetQueueWaitObserver(fn func(command string, wait time.Duration)) {
+	if fn == nil {
+		queueWaitObserver.Store(nil)
+		return
+	}
+	queueWaitObserver.Store(&fn)
+}
+
+// acquireCommandSlot blocks until a command is allowed to run, the returned func
+// must be called to release the slot after the command has finished
+func acquireCommandSlot(command string) func() {
+	begin := time.Now()
+	sem := cmdSemaphore.Load()
+	if sem != nil {
+		*sem <- struct{}{}
+	}
+
+	if fn := queueWaitObserver.Load(); fn != nil {
+		(*fn)(command, time.Since(begin))
+	}
+
+	if sem == nil {
+		return func() {}
+	}
+	return func() {
+		<-*sem
+	}
+}
+
+// commandName returns the omreport command without arguments, e.g., "storage pdisk"
+// for "storage pdisk controller=0"
+func commandName(args []string) string {
+	parts := []string{}
+	for _, arg := range args {
+		if strings.Contains(arg, "=") || strings.HasPrefix(arg, "-") {
+			continue
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
 func hasKeys(in map[string]string, fields ...string) bool {
 	for _, field := range fields {
 		field = normalizeName(field)
@@ -377,6 +430,7 @@ func parseOutput(mode ReaderMode, input string) Output {
 				output[ri].Lines = append(output[ri].Lines, Line{
 					normalizeName(sp[0]): sp[1],
 				})
+				output[ri].Raw = append(output[ri].Raw, line)
 			} else if mode <= TableReaderMode && keyLine != line {
 				l := Line{}
 				for i, s := range sp {
@@ -388,6 +442,7 @@ func parseOutput(mode ReaderMode, input string) Output {
 				}
 
 				output[ri].Lines = append(output[ri].Lines, l)
+				output[ri].Raw = append(output[ri].Raw, line)
 			}
 		}
 	}
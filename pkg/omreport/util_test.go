@@ -17,7 +17,11 @@ limitations under the License.
 package omreport
 
 import (
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -48,7 +52,93 @@ var severityTests = []SeverityTestResult{
 
 func TestSeverity(t *testing.T) {
 	for _, result := range severityTests {
-		value := severity(result.Input)
+		value := legacySeverity(result.Input)
 		assert.Equal(t, result.Output, value)
 	}
 }
+
+func TestUnmappedStates(t *testing.T) {
+	assert.Equal(t, "4", pdiskState("Failed"))
+	assert.Equal(t, "-1", pdiskState(""))
+	assert.Equal(t, "-1", vdiskState("Not a state"))
+	assert.Equal(t, "-1", vdiskReadPolicy("Not a policy"))
+	assert.Equal(t, "-1", vdiskWritePolicy("Not a policy"))
+	assert.Equal(t, "-1", vdiskCachePolicy("Not a policy"))
+}
+
+func TestIntrusionDetected(t *testing.T) {
+	assert.Equal(t, "0", intrusionDetected("Chassis is closed"))
+	assert.Equal(t, "1", intrusionDetected("Chassis is open"))
+	assert.Equal(t, "1", intrusionDetected("Chassis is breached"))
+	assert.Equal(t, StateUnknown, intrusionDetected("Unknown"))
+	assert.Equal(t, StateUnknown, intrusionDetected("[N/A]"))
+	assert.Equal(t, StateUnknown, intrusionDetected(""))
+}
+
+func TestStateMapping(t *testing.T) {
+	assert.Equal(t, "14", pdiskState("Non-RAID"))
+	assert.Equal(t, "14", pdiskState("Non RAID"))
+	assert.Equal(t, "14", pdiskState("non-raid"))
+	assert.Equal(t, "16", pdiskState("Blocked"))
+	assert.Equal(t, "13", vdiskState("Permanently Degraded"))
+	assert.Equal(t, "10", pdiskState(" SMART Alert Detected "))
+	assert.Equal(t, StateUnknown, pdiskState("Cryptographic Erased"))
+}
+
+func TestExtract(t *testing.T) {
+	v, err := extract("12.5 V", "V")
+	assert.NoError(t, err)
+	assert.Equal(t, "12.5", v)
+
+	_, err = extract("[N/A] V", "V")
+	assert.Error(t, err)
+
+	_, err = extractWatts("A W")
+	assert.Error(t, err)
+
+	assert.Equal(t, "-1", getNumberFromString("RAID-"))
+	assert.Equal(t, "-1", getNumberFromString(strings.Repeat("9", 400)))
+}
+
+func TestCommandName(t *testing.T) {
+	assert.Equal(t, "chassis temps", commandName([]string{"chassis", "temps"}))
+	assert.Equal(t, "storage pdisk", commandName([]string{"storage", "pdisk", "controller=0"}))
+}
+
+func TestMaxConcurrency(t *testing.T) {
+	SetMaxConcurrency(2)
+	defer SetMaxConcurrency(0)
+
+	var running, maxRunning atomic.Int32
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			release := acquireCommandSlot("chassis")
+			defer release()
+
+			cur := running.Add(1)
+			for {
+				prev := maxRunning.Load()
+				if cur <= prev || maxRunning.CompareAndSwap(prev, cur) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), maxRunning.Load())
+}
+
+func TestWithCommandTimeout(t *testing.T) {
+	or := New(&Options{OMReportExecutable: "omreport"})
+	assert.Equal(t, 10*time.Second, or.getCommandTimeout())
+
+	o := or.WithCommandTimeout(30 * time.Second)
+	assert.Equal(t, 30*time.Second, o.getCommandTimeout())
+	assert.Equal(t, "omreport", o.getOMReportExecutable())
+	// The original OMReport must not be changed
+	assert.Equal(t, 10*time.Second, or.getCommandTimeout())
+}
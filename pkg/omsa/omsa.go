@@ -0,0 +1,282 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package omsa checks if the OMSA services omreport depends on are up and restarts them if they aren't.
+package omsa
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultProcDir is the proc filesystem the processes of the services are looked up in
+	DefaultProcDir = "/proc"
+
+	// commLength is the maximum length of a process name in /proc/<pid>/comm
+	commLength = 15
+	// socketTimeout is the timeout of connecting to the socket of a service
+	socketTimeout = 2 * time.Second
+)
+
+var (
+	// DefaultServices are the processes of the OMSA data manager (the dataeng service), omreport fails without them
+	DefaultServices = []string{"dsm_sa_datamgrd"}
+
+	// ErrServicesDown is wrapped by the error of Err if a service is down
+	ErrServicesDown = errors.New("OMSA services are down")
+)
+
+// Options of the checks and the restart of the services
+type Options struct {
+	// Services are the names of the processes which must be running
+	Services []string
+	// Sockets are the unix sockets which must accept connections, by service name,
+	// none by default as the socket of the data manager isn't a documented
+	// interface and its path differs between OMSA versions
+	Sockets map[string]string
+	// ProcDir is the proc filesystem the processes are looked up in, DefaultProcDir if empty
+	ProcDir string
+
+	// RestartCommand is run to restart the services if one is down, no restart is attempted if empty
+	RestartCommand []string
+	// RestartBackoff is the minimum time between two restart attempts
+	RestartBackoff time.Duration
+	// RestartTimeout is the time after which the restart command is killed
+	RestartTimeout time.Duration
+}
+
+// ServiceStatus is the result of the check of a service
+type ServiceStatus struct {
+	Name string
+	Up   bool
+	// Reason why the service is down
+	Reason string
+}
+
+// Preflight checks the services and restarts them
+type Preflight struct {
+	opts Options
+
+	mutex       sync.Mutex
+	lastRestart time.Time
+	restarting  bool
+	restarts    map[string]float64
+}
+
+// New returns a Preflight with the given options
+func New(opts Options) *Preflight {
+	if opts.ProcDir == "" {
+		opts.ProcDir = DefaultProcDir
+	}
+	return &Preflight{
+		opts:     opts,
+		restarts: map[string]float64{},
+	}
+}
+
+// Services returns the names of the checked services, sorted
+func (p *Preflight) Services() []string {
+	names := slices.Clone(p.opts.Services)
+	for name := range p.opts.Sockets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// Check returns the status of the services, a service is up if its process is running (if it is in
+// Services) and its socket accepts connections (if it is in Sockets)
+func (p *Preflight) Check() []ServiceStatus {
+	var processes map[string]bool
+	var processesErr error
+	if len(p.opts.Services) > 0 {
+		processes, processesErr = runningProcesses(p.opts.ProcDir)
+	}
+
+	statuses := []ServiceStatus{}
+	for _, name := range p.Services() {
+		status := ServiceStatus{Name: name, Up: true}
+		if slices.Contains(p.opts.Services, name) {
+			if processesErr != nil {
+				status.Up, status.Reason = false, fmt.Sprintf("couldn't list processes: %v", processesErr)
+			} else if !processes[name] && !processes[truncate(name, commLength)] {
+				status.Up, status.Reason = false, "not running"
+			}
+		}
+		if socket, ok := p.opts.Sockets[name]; ok && status.Up {
+			if err := checkSocket(socket); err != nil {
+				status.Up, status.Reason = false, fmt.Sprintf("socket not accepting connections: %v", err)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// Err returns an error wrapping ErrServicesDown which names the services which are down, nil if all are up
+func Err(statuses []ServiceStatus) error {
+	down := []string{}
+	for _, s := range statuses {
+		if !s.Up {
+			down = append(down, fmt.Sprintf("%s %s", s.Name, s.Reason))
+		}
+	}
+	if len(down) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrServicesDown, strings.Join(down, ", "))
+}
+
+// CanRestart returns true if a restart command is set
+func (p *Preflight) CanRestart() bool {
+	return len(p.opts.RestartCommand) > 0
+}
+
+// Restart runs the restart command, unless the last attempt was less than the RestartBackoff ago or a
+// restart is still running. It returns false if no restart was attempted.
+func (p *Preflight) Restart() (bool, error) {
+	if !p.beginRestart() {
+		return false, nil
+	}
+	err := p.runRestartCommand()
+	p.endRestart(err)
+	return true, err
+}
+
+// RestartAsync runs the restart command like Restart, but in the background, done is called with the
+// result once it finished. It returns false if no restart is attempted.
+func (p *Preflight) RestartAsync(done func(error)) bool {
+	if !p.beginRestart() {
+		return false
+	}
+	go func() {
+		err := p.runRestartCommand()
+		p.endRestart(err)
+		done(err)
+	}()
+	return true
+}
+
+// beginRestart returns true and marks a restart as running if a restart can be attempted
+func (p *Preflight) beginRestart() bool {
+	if !p.CanRestart() {
+		return false
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.restarting || (!p.lastRestart.IsZero() && time.Since(p.lastRestart) < p.opts.RestartBackoff) {
+		return false
+	}
+	p.restarting, p.lastRestart = true, time.Now()
+	return true
+}
+
+// endRestart counts the finished restart
+func (p *Preflight) endRestart(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.restarting = false
+	if err != nil {
+		p.restarts["failure"]++
+	} else {
+		p.restarts["success"]++
+	}
+}
+
+// runRestartCommand runs the restart command, the error contains its output if it failed
+func (p *Preflight) runRestartCommand() error {
+	ctx := context.Background()
+	if p.opts.RestartTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.opts.RestartTimeout)
+		defer cancel()
+	}
+	out, err := exec.CommandContext(ctx, p.opts.RestartCommand[0], p.opts.RestartCommand[1:]...).CombinedOutput()
+	if err != nil {
+		if out = bytes.TrimSpace(out); len(out) > 0 {
+			return fmt.Errorf("%w: %s", err, out)
+		}
+		return err
+	}
+	return nil
+}
+
+// Restarts returns the number of restart attempts by result ("success" or "failure")
+func (p *Preflight) Restarts() map[string]float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return map[string]float64{
+		"success": p.restarts["success"],
+		"failure": p.restarts["failure"],
+	}
+}
+
+// runningProcesses returns the names of the running processes, by the base name of their executable
+// (from the cmdline) and their (possibly truncated) comm
+func runningProcesses(procDir string) (map[string]bool, error) {
+	entries, err := os.ReadDir(procDir)
+	if err != nil {
+		return nil, err
+	}
+
+	processes := map[string]bool{}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.TrimLeft(entry.Name(), "0123456789") != "" {
+			continue
+		}
+		// The process might have exited since the directory was listed
+		if cmdline, err := os.ReadFile(filepath.Join(procDir, entry.Name(), "cmdline")); err == nil {
+			if argv0, _, _ := bytes.Cut(cmdline, []byte{0}); len(argv0) > 0 {
+				processes[filepath.Base(string(argv0))] = true
+			}
+		}
+		if comm, err := os.ReadFile(filepath.Join(procDir, entry.Name(), "comm")); err == nil {
+			processes[strings.TrimSpace(string(comm))] = true
+		}
+	}
+	return processes, nil
+}
+
+// checkSocket connects to the unix socket
+func checkSocket(path string) error {
+	conn, err := net.DialTimeout("unix", path, socketTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// truncate returns the first n bytes of s
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The dellhw_exporter Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package omsa
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeProcess creates the proc entry of a process
+func writeProcess(t *testing.T, procDir string, pid string, cmdline string, comm string) {
+	t.Helper()
+
+	dir := filepath.Join(procDir, pid)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cmdline"), []byte(cmdline), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "comm"), []byte(comm+"\n"), 0o644))
+}
+
+func TestCheck(t *testing.T) {
+	procDir := t.TempDir()
+	writeProcess(t, procDir, "1", "/sbin/init\x00splash\x00", "systemd")
+	// Kernel threads have an empty cmdline
+	writeProcess(t, procDir, "2", "", "kthreadd")
+	// The comm is truncated to 15 characters
+	writeProcess(t, procDir, "100", "", "dsm_sa_eventmgr")
+	require.NoError(t, os.MkdirAll(filepath.Join(procDir, "sys"), 0o755))
+
+	// The socket path is kept short, as unix socket paths are limited to ~100 characters
+	socketDir, err := os.MkdirTemp("", "omsa")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(socketDir) })
+	socket := filepath.Join(socketDir, "dm.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	p := New(Options{
+		Services: []string{"dsm_sa_datamgrd", "dsm_sa_eventmgrd"},
+		Sockets:  map[string]string{"dsm_sa_datamgrd": socket, "dsm_om_connsvcd": socket},
+		ProcDir:  procDir,
+	})
+	assert.Equal(t, []string{"dsm_om_connsvcd", "dsm_sa_datamgrd", "dsm_sa_eventmgrd"}, p.Services())
+
+	statuses := p.Check()
+	assert.Equal(t, []ServiceStatus{
+		{Name: "dsm_om_connsvcd", Up: true},
+		{Name: "dsm_sa_datamgrd", Up: false, Reason: "not running"},
+		{Name: "dsm_sa_eventmgrd", Up: true},
+	}, statuses)
+	err = Err(statuses)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrServicesDown))
+	assert.Equal(t, "OMSA services are down: dsm_sa_datamgrd not running", err.Error())
+
+	writeProcess(t, procDir, "200", "/opt/dell/srvadmin/sbin/dsm_sa_datamgrd\x00", "dsm_sa_datamgrd")
+	statuses = p.Check()
+	assert.True(t, statuses[1].Up)
+	assert.NoError(t, Err(statuses))
+
+	require.NoError(t, listener.Close())
+	statuses = p.Check()
+	assert.False(t, statuses[0].Up)
+	assert.False(t, statuses[1].Up)
+	assert.Contains(t, statuses[1].Reason, "socket not accepting connections")
+	assert.True(t, statuses[2].Up)
+
+	p = New(Options{Services: []string{"dsm_sa_datamgrd"}, ProcDir: filepath.Join(procDir, "missing")})
+	statuses = p.Check()
+	assert.False(t, statuses[0].Up)
+	assert.Contains(t, statuses[0].Reason, "couldn't list processes")
+}
+
+func TestRestart(t *testing.T) {
+	p := New(Options{})
+	assert.False(t, p.CanRestart())
+	restarted, err := p.Restart()
+	assert.False(t, restarted)
+	assert.NoError(t, err)
+
+	p = New(Options{RestartCommand: []string{"sh", "-c", "true"}, RestartBackoff: time.Hour})
+	assert.True(t, p.CanRestart())
+	restarted, err = p.Restart()
+	assert.True(t, restarted)
+	assert.NoError(t, err)
+
+	// No restart is attempted within the backoff
+	restarted, err = p.Restart()
+	assert.False(t, restarted)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"success": 1, "failure": 0}, p.Restarts())
+
+	p = New(Options{RestartCommand: []string{"sh", "-c", "echo dataeng failed; exit 1"}})
+	restarted, err = p.Restart()
+	assert.True(t, restarted)
+	assert.EqualError(t, err, "exit status 1: dataeng failed")
+
+	p = New(Options{RestartCommand: []string{"sleep", "10"}, RestartTimeout: 10 * time.Millisecond})
+	_, err = p.Restart()
+	assert.Error(t, err)
+	assert.Equal(t, map[string]float64{"success": 0, "failure": 1}, p.Restarts())
+}
+
+func TestRestartAsync(t *testing.T) {
+	release := filepath.Join(t.TempDir(), "release")
+	p := New(Options{RestartCommand: []string{"sh", "-c", `while [ ! -e "$0" ]; do sleep 0.01; done`, release}})
+
+	done := make(chan error, 1)
+	assert.True(t, p.RestartAsync(func(err error) { done <- err }))
+	// No other restart is attempted while the restart is running, the counters can be read meanwhile
+	assert.False(t, p.RestartAsync(func(error) {}))
+	restarted, err := p.Restart()
+	assert.False(t, restarted)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"success": 0, "failure": 0}, p.Restarts())
+
+	require.NoError(t, os.WriteFile(release, nil, 0o644))
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "restart didn't finish")
+	}
+	assert.Equal(t, map[string]float64{"success": 1, "failure": 0}, p.Restarts())
+
+	assert.False(t, New(Options{}).RestartAsync(func(error) {}))
+}